@@ -0,0 +1,7 @@
+// FILE: lixenwraith/chess/cmd/chess-client-cli/bridge_native.go
+//go:build !js && !wasm
+
+package main
+
+// registerBridge has nothing to expose outside the browser
+func registerBridge() {}
\ No newline at end of file
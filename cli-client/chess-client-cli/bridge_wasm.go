@@ -0,0 +1,36 @@
+// FILE: lixenwraith/chess/cmd/chess-client-cli/bridge_wasm.go
+//go:build js && wasm
+
+package main
+
+import (
+	"errors"
+	"syscall/js"
+
+	"chess/internal/client/rules"
+)
+
+// registerBridge exposes client helpers to the hosting page
+func registerBridge() {
+	js.Global().Set("chessValidateFEN", js.FuncOf(validateFEN))
+}
+
+// validateFEN backs chessValidateFEN(fen): it returns an array of
+// {field, rank, message} diagnostics, empty when the FEN is a legal position
+func validateFEN(this js.Value, args []js.Value) any {
+	if len(args) < 1 || args[0].Type() != js.TypeString {
+		return []any{map[string]any{"field": 0, "rank": 0, "message": "expected a FEN string"}}
+	}
+
+	out := []any{}
+	_, err := rules.CheckFEN(args[0].String())
+	var errs rules.FENErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			out = append(out, map[string]any{"field": e.Field, "rank": e.Rank, "message": e.Error()})
+		}
+	} else if err != nil {
+		out = append(out, map[string]any{"field": 0, "rank": 0, "message": err.Error()})
+	}
+	return out
+}
\ No newline at end of file
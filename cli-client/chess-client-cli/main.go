@@ -7,14 +7,18 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"chess/internal/client/api"
+	"chess/internal/client/clock"
 	"chess/internal/client/command"
 	"chess/internal/client/display"
+	"chess/internal/client/rules"
 	"chess/internal/client/session"
 )
 
 func main() {
+	registerBridge()
 	for {
 		if !runClient() {
 			break
@@ -30,18 +34,19 @@ func runClient() (restart bool) {
 		}
 	}()
 
-	s := &session.Session{
-		APIBaseURL: "http://localhost:8080",
-		Client:     api.New("http://localhost:8080"),
-		Verbose:    false,
+	s := session.New("http://localhost:8080", api.New("http://localhost:8080"))
+	if path := session.DefaultGameLogPath(); path != "" {
+		if err := s.LoadGameLog(path); err != nil {
+			display.Println(display.Yellow, "Game history unavailable: %s", err.Error())
+		}
 	}
 
 	// Initialize simple input scanner
 	scanner := bufio.NewScanner(os.Stdin)
 
 	display.Println(display.Cyan, "Chess Debug Client")
-	display.Println(display.Cyan, "API: %s", s.APIBaseURL)
-	fmt.Println("Type 'help' for commands\n")
+	display.Println(display.Cyan, "API: %s", s.GetAPIBaseURL())
+	fmt.Print("Type 'help' for commands\n\n")
 
 	registry := command.NewRegistry(s)
 
@@ -71,10 +76,10 @@ func runClient() (restart bool) {
 
 		// Check for verbose flag
 		if strings.HasSuffix(line, " -v") {
-			s.Verbose = true
+			s.SetVerbose(true)
 			line = strings.TrimSuffix(line, " -v")
 		} else {
-			s.Verbose = false
+			s.SetVerbose(false)
 		}
 
 		registry.Execute(line)
@@ -86,50 +91,95 @@ func runClient() (restart bool) {
 func buildPrompt(s *session.Session) string {
 	var b display.Builder
 	b.Add("", "chess")
+	if s.IsOffline() {
+		b.Add(display.Magenta, " offline")
+	}
+
+	username := s.GetUsername()
+	currentGame := s.GetCurrentGame()
+	gameState := s.GetGameState()
+	playerColor := s.GetPlayerColor()
 
 	// Add user/game context
-	if s.Username != "" {
-		b.Add("", " [").Add(display.Magenta, s.Username)
-		if s.CurrentGame != "" {
+	if username != "" {
+		b.Add("", " [").Add(display.Magenta, username)
+		if currentGame != "" {
 			b.Add(display.Yellow, " - ")
 		} else {
 			b.Add("", "]")
 		}
 	}
 
-	if s.CurrentGame != "" {
-		if s.Username == "" {
+	if currentGame != "" {
+		if username == "" {
 			b.Add("", " [")
 		}
-		b.Add(display.White, s.CurrentGame[:8])
+		b.Add(display.White, currentGame[:8])
 		b.Add("", "]")
 	}
 
 	// Add player color if in game
-	if s.CurrentGameState != nil && s.PlayerColor != "" {
-		if s.PlayerColor == "w" {
+	if gameState != nil && playerColor.Valid() {
+		if playerColor == api.White {
 			b.Add("", " ").Add(display.Blue, "White")
 		} else {
 			b.Add("", " ").Add(display.Red, "Black")
 		}
 	}
 
+	// A game lost on time is over on the client though the server still
+	// has it ongoing
+	gameClock := s.GetClock(currentGame)
+	flagged := api.NoColor
+	if gameClock != nil {
+		flagged = gameClock.Flagged(time.Now())
+	}
+
 	// Add game state if available
-	if s.CurrentGameState != nil {
+	if flagged.Valid() {
+		b.Add("", " - ").Add(display.Red, fmt.Sprintf("%s lost on time", flagged))
+	} else if gameState != nil && gameState.State.IsOver() {
+		switch gameState.State.Winner() {
+		case api.White:
+			b.Add("", " - ").Add(display.Blue, "White wins")
+		case api.Black:
+			b.Add("", " - ").Add(display.Red, "Black wins")
+		default:
+			b.Add("", " - ").Add(display.Yellow, "Drawn")
+		}
+	} else if gameState != nil {
 		turnInfo := " - Turn:"
-		if s.CurrentGameState.Turn == "w" {
-			playerType := "h"
-			if s.CurrentGameState.Players.White.Type == 2 {
-				playerType = "c"
-			}
+		playerType := gameState.Players.Player(gameState.Turn).Type.Short()
+		if gameState.Turn == api.White {
 			b.Add("", turnInfo).Add(display.Blue, "White").Add("", fmt.Sprintf("(%s)", playerType))
 		} else {
-			playerType := "h"
-			if s.CurrentGameState.Players.Black.Type == 2 {
-				playerType = "c"
-			}
 			b.Add("", turnInfo).Add(display.Red, "Black").Add("", fmt.Sprintf("(%s)", playerType))
 		}
+		if gameState.DrawOffer.Valid() {
+			b.Add(display.Yellow, fmt.Sprintf(" %s offers draw", gameState.DrawOffer))
+		}
+		// Draw claims coming within reach
+		if history := s.Replay(gameState); history != nil {
+			if n := history.Repetitions(); n >= 2 {
+				b.Add(display.Yellow, fmt.Sprintf(" rep %dx", n))
+			}
+		}
+		if pos, err := rules.ParseFEN(gameState.FEN); err == nil && pos.Halfmove >= rules.WarnPlies {
+			b.Add(display.Yellow, fmt.Sprintf(" 50-move %d/%d", pos.Halfmove, rules.ClaimPlies))
+		}
+	}
+
+	// Clock times, the running one highlighted
+	if gameClock != nil && gameState != nil {
+		now := time.Now()
+		running := gameClock.Turn()
+		for _, color := range []api.Color{api.White, api.Black} {
+			timeColor := display.White
+			if color == running {
+				timeColor = display.Green
+			}
+			b.Add("", " ").Add(timeColor, clock.Format(gameClock.Remaining(color, now)))
+		}
 	}
 
 	return display.Prompt(b.String())
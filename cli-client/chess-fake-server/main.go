@@ -0,0 +1,28 @@
+// FILE: lixenwraith/chess/cmd/chess-fake-server/main.go
+// Package main serves the in-memory fake chess API for offline client development.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"chess/internal/client/clienttest"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "listen address")
+	latency := flag.Duration("latency", 0, "artificial delay added to every response")
+	computerDelay := flag.Duration("computer-delay", 500*time.Millisecond, "time computer moves stay pending")
+	pollTimeout := flag.Duration("poll-timeout", clienttest.DefaultPollTimeout, "long-poll timeout")
+	flag.Parse()
+
+	f := clienttest.NewFake()
+	f.SetLatency(*latency)
+	f.SetComputerDelay(*computerDelay)
+	f.SetPollTimeout(*pollTimeout)
+
+	log.Printf("fake chess server listening on http://%s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, f))
+}
@@ -0,0 +1,47 @@
+// FILE: lixenwraith/chess/cmd/chess-fake-uci/main.go
+// Package main is a scripted UCI engine for exercising engine integrations.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/rules"
+	"chess/internal/client/uci"
+)
+
+func main() {
+	name := flag.String("name", "fake-uci", "engine name reported to the GUI")
+	script := flag.String("moves", "", "comma-separated moves to play in order, each used only when legal")
+	delay := flag.Duration("delay", 0, "time spent on every search")
+	stall := flag.Duration("stall", 0, "time the first search runs past its budget, ignoring stop")
+	flag.Parse()
+
+	var moves []string
+	if *script != "" {
+		moves = strings.Split(*script, ",")
+	}
+
+	// Play the next scripted move if it is legal, else the first legal move
+	search := func(pos *rules.Position, level int, budget time.Duration) (*api.MoveInfo, error) {
+		time.Sleep(min(*delay, budget) + *stall)
+		*stall = 0
+		for len(moves) > 0 {
+			next := moves[0]
+			moves = moves[1:]
+			if m, err := pos.ParseMove(next); err == nil {
+				return &api.MoveInfo{Move: m.String(), Depth: 1}, nil
+			}
+		}
+		return &api.MoveInfo{Move: pos.LegalMoves()[0].String(), Depth: 1}, nil
+	}
+
+	if err := uci.NewFuncAdapter(*name, 0, search).Run(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "chess-fake-uci: %v\n", err)
+		os.Exit(1)
+	}
+}
\ No newline at end of file
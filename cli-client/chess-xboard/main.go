@@ -0,0 +1,35 @@
+// FILE: lixenwraith/chess/cmd/chess-xboard/main.go
+// Package main lets XBoard-compatible interfaces play against the chess server.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"chess/internal/client/api"
+	"chess/internal/client/cecp"
+	"chess/internal/client/engine"
+)
+
+func main() {
+	url := flag.String("url", "http://localhost:8080", "chess server API URL")
+	level := flag.Int("level", engine.MaxLevel, "engine level 0-20")
+	token := flag.String("token", "", "bearer token for servers that require login")
+	flag.Parse()
+
+	if !strings.HasPrefix(*url, "http://") && !strings.HasPrefix(*url, "https://") {
+		*url = "http://" + *url
+	}
+
+	// stdout carries the protocol, so request tracing must stay off
+	client := api.New(*url)
+	client.SetQuiet(true)
+	client.SetToken(*token)
+
+	if err := cecp.NewBridge(client, *level).Run(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "chess-xboard: %v\n", err)
+		os.Exit(1)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,217 @@
+// FILE: lixenwraith/chess/internal/client/api/cassette.go
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+// secretFields are the JSON body fields whose values are never recorded
+var secretFields = map[string]bool{"password": true, "token": true}
+
+// Cassette is a recorded sequence of API interactions
+type Cassette struct {
+	Recorded     time.Time     `json:"recorded"`
+	BaseURL      string        `json:"baseUrl"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one request/response pair; Path includes the query string
+type Interaction struct {
+	Method       string            `json:"method"`
+	Path         string            `json:"path"`
+	Headers      map[string]string `json:"headers,omitempty"`
+	RequestBody  string            `json:"requestBody,omitempty"`
+	Status       int               `json:"status"`
+	ResponseBody string            `json:"responseBody,omitempty"`
+}
+
+// LoadCassette reads a cassette file
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette as indented JSON
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+type recording struct {
+	path     string
+	cassette *Cassette
+}
+
+// StartRecording captures every subsequent request/response pair until
+// StopRecording writes them to path
+func (c *Client) StartRecording(path string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.recording != nil {
+		return fmt.Errorf("already recording to %s", c.recording.path)
+	}
+	c.recording = &recording{
+		path: path,
+		cassette: &Cassette{
+			Recorded:     time.Now().UTC(),
+			BaseURL:      c.baseURL,
+			Interactions: []Interaction{},
+		},
+	}
+	return nil
+}
+
+// StopRecording saves the cassette and returns its file and interaction count
+func (c *Client) StopRecording() (path string, count int, err error) {
+	c.mu.Lock()
+	rec := c.recording
+	c.recording = nil
+	c.mu.Unlock()
+
+	if rec == nil {
+		return "", 0, fmt.Errorf("not recording")
+	}
+	return rec.path, len(rec.cassette.Interactions), rec.cassette.Save(rec.path)
+}
+
+// IsRecording reports the active cassette file, if any
+func (c *Client) IsRecording() (path string, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.recording == nil {
+		return "", false
+	}
+	return c.recording.path, true
+}
+
+// add appends a completed exchange; transport failures have nothing to replay
+func (r *recording) add(ex *Exchange) {
+	if ex.Status == 0 {
+		return
+	}
+
+	headers := make(map[string]string, len(ex.RequestHeader))
+	for name := range ex.RequestHeader {
+		headers[name] = ex.RequestHeader.Get(name)
+	}
+	if _, ok := headers["Authorization"]; ok {
+		headers["Authorization"] = "Bearer " + redacted
+	}
+
+	path := ex.URL
+	if u, err := url.Parse(ex.URL); err == nil {
+		path = u.RequestURI()
+	}
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Method:       ex.Method,
+		Path:         path,
+		Headers:      headers,
+		RequestBody:  redactBody(ex.RequestBody),
+		Status:       ex.Status,
+		ResponseBody: redactBody(string(ex.ResponseBody)),
+	})
+}
+
+// redactBody replaces secret fields anywhere in a JSON body. Bodies that
+// are not JSON or hold no secrets are returned unchanged
+func redactBody(body string) string {
+	var v any
+	if err := json.Unmarshal([]byte(body), &v); err != nil || !redactValue(v) {
+		return body
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return string(data)
+}
+
+// redactValue redacts secret fields in a decoded JSON value in place and
+// reports whether it found any
+func redactValue(v any) bool {
+	found := false
+	switch v := v.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if secretFields[key] {
+				v[key] = redacted
+				found = true
+			} else if redactValue(field) {
+				found = true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if redactValue(item) {
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+// ReplayTransport serves recorded interactions instead of contacting a
+// server. Requests are matched by method and path in recorded order
+type ReplayTransport struct {
+	mu        sync.Mutex
+	remaining []Interaction
+}
+
+func NewReplayTransport(c *Cassette) *ReplayTransport {
+	return &ReplayTransport{remaining: append([]Interaction{}, c.Interactions...)}
+}
+
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, in := range t.remaining {
+		if in.Method != req.Method || in.Path != req.URL.RequestURI() {
+			continue
+		}
+		t.remaining = append(t.remaining[:i], t.remaining[i+1:]...)
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Status, http.StatusText(in.Status)),
+			StatusCode:    in.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": {"application/json"}},
+			Body:          io.NopCloser(bytes.NewReader([]byte(in.ResponseBody))),
+			ContentLength: int64(len(in.ResponseBody)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("replay: no recorded interaction for %s %s", req.Method, req.URL.RequestURI())
+}
+
+// Remaining returns the number of recorded interactions not yet served
+func (t *ReplayTransport) Remaining() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.remaining)
+}
@@ -0,0 +1,95 @@
+// FILE: lixenwraith/chess/internal/client/api/cassette_test.go
+package api_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"chess/internal/client/api"
+	"chess/internal/client/clienttest"
+)
+
+const cassettePassword = "correct-horse-battery"
+
+// playSession runs a fixed sequence of calls and returns the game it played
+func playSession(t *testing.T, c *api.Client) *api.GameResponse {
+	t.Helper()
+	if _, err := c.Register("alice", cassettePassword, ""); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	auth, err := c.Login("alice", cassettePassword)
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	c.SetToken(auth.Token)
+	game, err := c.CreateGame(&api.CreateGameRequest{
+		White: api.PlayerConfig{Type: api.Human},
+		Black: api.PlayerConfig{Type: api.Human},
+	})
+	if err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	if _, err := c.MakeMove(game.GameID, "e2e4"); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	game, err = c.GetGame(game.GameID)
+	if err != nil {
+		t.Fatalf("get game: %v", err)
+	}
+	return game
+}
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	_, srv := clienttest.NewServer()
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	live := api.New(srv.URL)
+	live.SetQuiet(true)
+	if err := live.StartRecording(path); err != nil {
+		t.Fatal(err)
+	}
+	recorded := playSession(t, live)
+	if _, count, err := live.StopRecording(); err != nil || count != 5 {
+		t.Fatalf("stop recording: %d interactions, %v", count, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), cassettePassword) || strings.Contains(string(data), live.Token()) {
+		t.Fatalf("cassette holds a secret:\n%s", data)
+	}
+
+	cassette, err := api.LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, in := range cassette.Interactions {
+		if strings.HasPrefix(in.Path, "/api/v1/auth/") && !strings.Contains(in.RequestBody, `"password":"[REDACTED]"`) {
+			t.Errorf("%s %s: password not redacted in %s", in.Method, in.Path, in.RequestBody)
+		}
+		if strings.HasPrefix(in.Path, "/api/v1/auth/") && !strings.Contains(in.ResponseBody, `"token":"[REDACTED]"`) {
+			t.Errorf("%s %s: token not redacted in %s", in.Method, in.Path, in.ResponseBody)
+		}
+	}
+
+	// Replay without a server
+	srv.Close()
+	replay := api.NewReplayTransport(cassette)
+	offline := api.New(srv.URL)
+	offline.SetQuiet(true)
+	offline.HTTPClient.Transport = replay
+	replayed := playSession(t, offline)
+
+	if !reflect.DeepEqual(replayed, recorded) {
+		t.Fatalf("replayed game %+v, recorded %+v", replayed, recorded)
+	}
+	if replay.Remaining() != 0 {
+		t.Fatalf("%d interactions not replayed", replay.Remaining())
+	}
+}
\ No newline at end of file
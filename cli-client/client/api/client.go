@@ -7,7 +7,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"chess/internal/client/display"
@@ -15,37 +18,95 @@ import (
 
 const HttpTimeout = 30 * time.Second
 
+// Client is safe for concurrent use; mutable configuration is guarded and
+// snapshotted at the start of every request
 type Client struct {
-	BaseURL    string
-	AuthToken  string
 	HTTPClient *http.Client
-	Verbose    bool
+
+	mu        sync.RWMutex
+	baseURL   string
+	authToken string
+	verbose   bool
+	quiet     bool
+	recording *recording
+	history   []*Exchange
+	stats     map[string]*RouteStats
 }
 
 func New(baseURL string) *Client {
 	return &Client{
-		BaseURL: baseURL,
+		baseURL: strings.TrimRight(baseURL, "/"),
 		HTTPClient: &http.Client{
 			Timeout: HttpTimeout,
 		},
 	}
 }
 
+// Clone returns an independent copy sharing the underlying HTTP client, so
+// parallel callers can hold their own token and URL without interfering
+func (c *Client) Clone() *Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return &Client{
+		HTTPClient: c.HTTPClient,
+		baseURL:    c.baseURL,
+		authToken:  c.authToken,
+		verbose:    c.verbose,
+		quiet:      c.quiet,
+	}
+}
+
 func (c *Client) SetVerbose(v bool) {
-	c.Verbose = v
+	c.mu.Lock()
+	c.verbose = v
+	c.mu.Unlock()
+}
+
+func (c *Client) IsVerbose() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.verbose
+}
+
+// SetQuiet suppresses all request tracing, for bulk callers such as load tests
+func (c *Client) SetQuiet(q bool) {
+	c.mu.Lock()
+	c.quiet = q
+	c.mu.Unlock()
 }
 
 // SetBaseURL updates the API base URL for the client
 func (c *Client) SetBaseURL(url string) {
-	c.BaseURL = strings.TrimRight(url, "/")
+	c.mu.Lock()
+	c.baseURL = strings.TrimRight(url, "/")
+	c.mu.Unlock()
+}
+
+func (c *Client) BaseURL() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.baseURL
 }
 
 func (c *Client) SetToken(token string) {
-	c.AuthToken = token
+	c.mu.Lock()
+	c.authToken = token
+	c.mu.Unlock()
+}
+
+func (c *Client) Token() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.authToken
 }
 
 func (c *Client) doRequest(method, path string, body any, result any) error {
-	url := c.BaseURL + path
+	// Snapshot configuration so concurrent setters cannot tear a request
+	c.mu.RLock()
+	baseURL, authToken, verbose, quiet := c.baseURL, c.authToken, c.verbose, c.quiet
+	c.mu.RUnlock()
+
+	url := baseURL + path
 
 	// Prepare body
 	var bodyReader io.Reader
@@ -69,64 +130,77 @@ func (c *Client) doRequest(method, path string, body any, result any) error {
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
-	if c.AuthToken != "" {
-		req.Header.Set("Authorization", "Bearer "+c.AuthToken)
+	if authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+authToken)
 	}
 
 	// Display request
-	display.Print(display.Blue, "\n[API] %s %s\n", method, path)
-	if bodyStr != "" {
-		if c.Verbose {
-			// Display request body if verbose
-			var prettyBody any
-			json.Unmarshal([]byte(bodyStr), &prettyBody)
-			prettyJSON, _ := json.MarshalIndent(prettyBody, "", "  ")
-			display.Println(display.Cyan, "Request Body:")
-			display.Println(display.Reset, string(prettyJSON))
-		} else {
-			display.Print(display.Blue, "%s\n", bodyStr)
+	if !quiet {
+		display.Print(display.Blue, "\n[API] %s %s\n", method, path)
+		if bodyStr != "" {
+			if verbose {
+				// Display request body if verbose
+				var prettyBody any
+				json.Unmarshal([]byte(bodyStr), &prettyBody)
+				prettyJSON, _ := json.MarshalIndent(prettyBody, "", "  ")
+				display.Println(display.Cyan, "Request Body:")
+				display.Println(display.Reset, "%s", prettyJSON)
+			} else {
+				display.Print(display.Blue, "%s\n", bodyStr)
+			}
 		}
 	}
 
 	// Execute request
+	ex, req, tr := newExchange(req, bodyStr)
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		display.Print(display.Red, "[ERROR] %s\n", err.Error())
+		ex.finish(tr, nil, nil, err)
+		c.remember(ex)
+		if !quiet {
+			display.Print(display.Red, "[ERROR] %s\n", err.Error())
+		}
 		return err
 	}
 	defer resp.Body.Close()
 
 	// Read response
 	respBody, err := io.ReadAll(resp.Body)
+	ex.finish(tr, resp, respBody, err)
+	c.remember(ex)
 	if err != nil {
 		return err
 	}
 
 	// Display response
-	statusColor := display.Green
-	if resp.StatusCode >= 400 {
-		statusColor = display.Red
+	if !quiet {
+		statusColor := display.Green
+		if resp.StatusCode >= 400 {
+			statusColor = display.Red
+		}
+		fmt.Printf("%s[%d %s]%s\n", statusColor, resp.StatusCode, http.StatusText(resp.StatusCode), display.Reset)
 	}
-	fmt.Printf("%s[%d %s]%s\n", statusColor, resp.StatusCode, http.StatusText(resp.StatusCode), display.Reset)
 
 	// Display response body if verbose
-	if c.Verbose && len(respBody) > 0 {
+	if verbose && !quiet && len(respBody) > 0 {
 		var prettyResp any
 		if err := json.Unmarshal(respBody, &prettyResp); err == nil {
 			prettyJSON, _ := json.MarshalIndent(prettyResp, "", "  ")
 			display.Println(display.Cyan, "Response Body:")
-			display.Println(display.Reset, string(prettyJSON))
+			display.Println(display.Reset, "%s", prettyJSON)
 		} else {
 			display.Println(display.Cyan, "Response:")
-			display.Println(display.Reset, string(respBody))
+			display.Println(display.Reset, "%s", respBody)
 		}
 	}
 
 	// Parse error response
 	if resp.StatusCode >= 400 {
 		var errResp ErrorResponse
+		apiErr := &APIError{Status: resp.StatusCode, Body: respBody}
 		if err := json.Unmarshal(respBody, &errResp); err == nil {
-			if !c.Verbose {
+			apiErr.Response = &errResp
+			if !verbose && !quiet {
 				display.Print(display.Red, "Error: %s\n", errResp.Error)
 				if errResp.Code != "" {
 					display.Print(display.Red, "Code: %s\n", errResp.Code)
@@ -135,20 +209,27 @@ func (c *Client) doRequest(method, path string, body any, result any) error {
 					display.Print(display.Red, "Details: %s\n", errResp.Details)
 				}
 			}
-		} else if !c.Verbose {
-			display.Println(display.Red, string(respBody))
+		} else if !verbose && !quiet {
+			display.Println(display.Red, "%s", respBody)
 		}
-		return fmt.Errorf("request failed with status %d", resp.StatusCode)
+		return apiErr
 	}
 
 	// Parse success response
 	if result != nil && len(respBody) > 0 {
 		if err := json.Unmarshal(respBody, result); err != nil {
 			// For debug, show raw response if parsing fails
-			display.Print(display.Red, "Response parse error: %s\n", err.Error())
-			display.Print(display.Green, "Raw response: %s\n", string(respBody))
+			if !quiet {
+				display.Print(display.Red, "Response parse error: %s\n", err.Error())
+				display.Print(display.Green, "Raw response: %s\n", string(respBody))
+			}
 			return err
 		}
+		if w, ok := result.(interface{ Warnings() []string }); ok && !quiet {
+			for _, msg := range w.Warnings() {
+				display.Print(display.Yellow, "Warning: %s\n", msg)
+			}
+		}
 	}
 
 	return nil
@@ -168,6 +249,34 @@ func (c *Client) CreateGame(req *CreateGameRequest) (*GameResponse, error) {
 	return &resp, err
 }
 
+// ListGames returns one page of games matching q
+func (c *Client) ListGames(q *ListGamesQuery) (*GameListResponse, error) {
+	params := url.Values{}
+	if q.State != "" {
+		params.Set("state", q.State)
+	}
+	if q.Mine {
+		params.Set("player", "me")
+	}
+	if q.Opponent.Valid() {
+		params.Set("opponent", q.Opponent.String())
+	}
+	if q.Limit > 0 {
+		params.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.Offset > 0 {
+		params.Set("offset", strconv.Itoa(q.Offset))
+	}
+
+	path := "/api/v1/games"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	var resp GameListResponse
+	err := c.doRequest("GET", path, nil, &resp)
+	return &resp, err
+}
+
 func (c *Client) GetGame(gameID string) (*GameResponse, error) {
 	var resp GameResponse
 	err := c.doRequest("GET", "/api/v1/games/"+gameID, nil, &resp)
@@ -199,6 +308,21 @@ func (c *Client) UndoMoves(gameID string, count int) (*GameResponse, error) {
 	return &resp, err
 }
 
+// Resign ends the game as a loss for color
+func (c *Client) Resign(gameID string, color Color) (*GameResponse, error) {
+	req := &ResignRequest{Color: color}
+	var resp GameResponse
+	err := c.doRequest("POST", "/api/v1/games/"+gameID+"/resign", req, &resp)
+	return &resp, err
+}
+
+// Draw offers, accepts, declines or claims a draw
+func (c *Client) Draw(gameID string, req *DrawRequest) (*GameResponse, error) {
+	var resp GameResponse
+	err := c.doRequest("POST", "/api/v1/games/"+gameID+"/draw", req, &resp)
+	return &resp, err
+}
+
 func (c *Client) GetBoard(gameID string) (*BoardResponse, error) {
 	var resp BoardResponse
 	err := c.doRequest("GET", "/api/v1/games/"+gameID+"/board", nil, &resp)
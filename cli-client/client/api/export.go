@@ -0,0 +1,243 @@
+// FILE: lixenwraith/chess/internal/client/api/export.go
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TokenVariable stands in for the bearer token in redacted exports
+const TokenVariable = "$CHESS_TOKEN"
+
+// Curl renders the exchange as a reproducible curl command. Unless
+// includeToken is set, the bearer token is replaced by TokenVariable and
+// passwords and tokens in the body are redacted
+func (ex *Exchange) Curl(includeToken bool) string {
+	parts := []string{"curl", "-X", ex.Method, shellQuote(ex.URL)}
+
+	for _, name := range sortedHeaderNames(ex.RequestHeader) {
+		value := ex.RequestHeader.Get(name)
+		if name == "Authorization" && !includeToken {
+			// Double quotes let the shell expand the variable
+			parts = append(parts, "-H", `"Authorization: Bearer `+TokenVariable+`"`)
+			continue
+		}
+		parts = append(parts, "-H", shellQuote(name+": "+value))
+	}
+	if ex.RequestBody != "" {
+		parts = append(parts, "--data-raw", shellQuote(exportBody(ex.RequestBody, includeToken)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// shellQuote wraps s in single quotes for POSIX shells
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func sortedHeaderNames(h http.Header) []string {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HAR 1.2 document types, see http://www.softwareishard.com/blog/har-12-spec/
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+// WriteHAR exports exchanges as a HAR 1.2 archive. Unless includeToken is
+// set, bearer tokens are redacted, and so are passwords and tokens in the
+// request and response bodies
+func WriteHAR(w io.Writer, exchanges []*Exchange, includeToken bool) error {
+	doc := harFile{Log: harLog{
+		Version: "1.2",
+		Creator: harCreator{Name: "chess-client-cli", Version: "1.0"},
+		Entries: make([]harEntry, 0, len(exchanges)),
+	}}
+	for _, ex := range exchanges {
+		doc.Log.Entries = append(doc.Log.Entries, harEntryFor(ex, includeToken))
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+func harEntryFor(ex *Exchange, includeToken bool) harEntry {
+	e := harEntry{
+		StartedDateTime: ex.Started.Format(time.RFC3339Nano),
+		Time:            millis(ex.Timings.Total),
+		Request: harRequest{
+			Method:      ex.Method,
+			URL:         ex.URL,
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(ex.RequestHeader, includeToken),
+			QueryString: []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(ex.RequestBody),
+		},
+		Response: harResponse{
+			Status:      ex.Status,
+			StatusText:  http.StatusText(ex.Status),
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(ex.ResponseHeader, true),
+			Content: harContent{
+				Size:     len(ex.ResponseBody),
+				MimeType: ex.ResponseHeader.Get("Content-Type"),
+				Text:     exportBody(string(ex.ResponseBody), includeToken),
+			},
+			HeadersSize: -1,
+			BodySize:    len(ex.ResponseBody),
+		},
+		Timings: harTimings{
+			Blocked: -1,
+			DNS:     -1,
+			Connect: -1,
+			SSL:     -1,
+			Send:    millis(ex.Timings.Send),
+			Wait:    millis(ex.Timings.Wait),
+			Receive: millis(ex.Timings.Receive),
+		},
+	}
+
+	if u, err := url.Parse(ex.URL); err == nil {
+		for name, values := range u.Query() {
+			for _, v := range values {
+				e.Request.QueryString = append(e.Request.QueryString, harNameValue{Name: name, Value: v})
+			}
+		}
+	}
+	if ex.RequestBody != "" {
+		e.Request.PostData = &harPostData{
+			MimeType: ex.RequestHeader.Get("Content-Type"),
+			Text:     exportBody(ex.RequestBody, includeToken),
+		}
+	}
+	if ex.Err != nil {
+		e.Comment = ex.Err.Error()
+		e.Response.BodySize = -1
+	}
+	return e
+}
+
+func harHeaders(h http.Header, includeToken bool) []harNameValue {
+	headers := []harNameValue{}
+	for _, name := range sortedHeaderNames(h) {
+		for _, v := range h[name] {
+			if name == "Authorization" && !includeToken {
+				v = "Bearer " + redacted
+			}
+			headers = append(headers, harNameValue{Name: name, Value: v})
+		}
+	}
+	return headers
+}
+
+// exportBody redacts the secret fields of a JSON body unless the token is
+// to be included
+func exportBody(body string, includeToken bool) string {
+	if includeToken {
+		return body
+	}
+	return redactBody(body)
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// Summary renders a one-line description of the exchange
+func (ex *Exchange) Summary() string {
+	path := ex.URL
+	if u, err := url.Parse(ex.URL); err == nil {
+		path = u.RequestURI()
+	}
+	status := fmt.Sprintf("%d", ex.Status)
+	if ex.Err != nil {
+		status = "ERR"
+	}
+	return fmt.Sprintf("%-6s %-45s %3s %6.1fms", ex.Method, path, status, millis(ex.Timings.Total))
+}
@@ -0,0 +1,120 @@
+// FILE: lixenwraith/chess/internal/client/api/history.go
+package api
+
+import (
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// HistoryLimit bounds the number of exchanges kept by a client
+const HistoryLimit = 500
+
+// Exchange is one API call as seen by the client. Status is 0 and Err is set
+// when no response was received
+type Exchange struct {
+	Started        time.Time
+	Method         string
+	URL            string
+	RequestHeader  http.Header
+	RequestBody    string
+	Status         int
+	ResponseHeader http.Header
+	ResponseBody   []byte
+	Err            error
+	Timings        Timings
+}
+
+// Timings splits an exchange's duration into HAR phases
+type Timings struct {
+	Send    time.Duration // until the request was written
+	Wait    time.Duration // until the first response byte
+	Receive time.Duration // until the body was read
+	Total   time.Duration
+}
+
+// exchangeTrace collects timestamps from transport callbacks that may run on
+// other goroutines
+type exchangeTrace struct {
+	mu        sync.Mutex
+	wrote     time.Time
+	firstByte time.Time
+}
+
+func newExchange(req *http.Request, body string) (*Exchange, *http.Request, *exchangeTrace) {
+	ex := &Exchange{
+		Started:       time.Now(),
+		Method:        req.Method,
+		URL:           req.URL.String(),
+		RequestHeader: req.Header.Clone(),
+		RequestBody:   body,
+	}
+	tr := &exchangeTrace{}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			tr.mu.Lock()
+			tr.wrote = time.Now()
+			tr.mu.Unlock()
+		},
+		GotFirstResponseByte: func() {
+			tr.mu.Lock()
+			tr.firstByte = time.Now()
+			tr.mu.Unlock()
+		},
+	}))
+	return ex, req, tr
+}
+
+func (ex *Exchange) finish(tr *exchangeTrace, resp *http.Response, body []byte, err error) {
+	end := time.Now()
+	ex.Err = err
+	if resp != nil {
+		ex.Status = resp.StatusCode
+		ex.ResponseHeader = resp.Header.Clone()
+		ex.ResponseBody = body
+	}
+
+	tr.mu.Lock()
+	wrote, firstByte := tr.wrote, tr.firstByte
+	tr.mu.Unlock()
+
+	// Transports without trace support report the whole call as waiting
+	ex.Timings.Total = end.Sub(ex.Started)
+	if wrote.IsZero() || firstByte.IsZero() {
+		ex.Timings.Wait = ex.Timings.Total
+		return
+	}
+	ex.Timings.Send = wrote.Sub(ex.Started)
+	ex.Timings.Wait = firstByte.Sub(wrote)
+	ex.Timings.Receive = end.Sub(firstByte)
+}
+
+// remember stores a finished exchange in the history, the route statistics
+// and any active recording
+func (c *Client) remember(ex *Exchange) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.observe(ex)
+	c.history = append(c.history, ex)
+	if len(c.history) > HistoryLimit {
+		c.history = c.history[len(c.history)-HistoryLimit:]
+	}
+	if c.recording != nil {
+		c.recording.add(ex)
+	}
+}
+
+// History returns the recorded exchanges, oldest first
+func (c *Client) History() []*Exchange {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]*Exchange{}, c.history...)
+}
+
+// ClearHistory drops all recorded exchanges
+func (c *Client) ClearHistory() {
+	c.mu.Lock()
+	c.history = nil
+	c.mu.Unlock()
+}
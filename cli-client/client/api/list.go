@@ -0,0 +1,74 @@
+// FILE: lixenwraith/chess/internal/client/api/list.go
+package api
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DefaultListLimit is the page size used when a query sets none
+const DefaultListLimit = 20
+
+// Validate rejects queries a server would not understand
+func (q *ListGamesQuery) Validate() error {
+	switch q.State {
+	case "", "ongoing", "finished":
+	default:
+		return fmt.Errorf("invalid state filter %q, expected ongoing or finished", q.State)
+	}
+	if q.Opponent != 0 && !q.Opponent.Valid() {
+		return fmt.Errorf("invalid opponent filter %d", q.Opponent)
+	}
+	if q.Limit < 0 || q.Offset < 0 {
+		return fmt.Errorf("limit and offset must not be negative")
+	}
+	return nil
+}
+
+// Matches reports whether a game passes the query filters; userID is the
+// authenticated user the Mine filter refers to
+func (q *ListGamesQuery) Matches(g *GameSummary, userID string) bool {
+	switch q.State {
+	case "ongoing":
+		if g.State.IsOver() {
+			return false
+		}
+	case "finished":
+		if !g.State.IsOver() {
+			return false
+		}
+	}
+	if q.Mine && g.Players.White.ID != userID && g.Players.Black.ID != userID {
+		return false
+	}
+	if q.Opponent != 0 && g.Players.White.Type != q.Opponent && g.Players.Black.Type != q.Opponent {
+		return false
+	}
+	return true
+}
+
+// PageGames filters games, orders them most recently updated first and cuts
+// out the page selected by q. Servers without a listing endpoint can be
+// emulated with it
+func PageGames(games []GameSummary, q *ListGamesQuery, userID string) *GameListResponse {
+	var matched []GameSummary
+	for i := range games {
+		if q.Matches(&games[i], userID) {
+			matched = append(matched, games[i])
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].UpdatedAt.After(matched[j].UpdatedAt) })
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	start := min(q.Offset, len(matched))
+	end := min(start+limit, len(matched))
+	return &GameListResponse{
+		Games:  matched[start:end],
+		Total:  len(matched),
+		Offset: q.Offset,
+		Limit:  limit,
+	}
+}
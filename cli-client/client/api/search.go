@@ -0,0 +1,80 @@
+// FILE: lixenwraith/chess/internal/client/api/search.go
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Server search time limits, matching what the API accepts
+const (
+	MinSearchTime = 100 * time.Millisecond
+	MaxSearchTime = 10 * time.Second
+)
+
+const (
+	// searchSettle is how long past the search time a move may take to arrive
+	searchSettle = 5 * time.Second
+	searchPoll   = 100 * time.Millisecond
+)
+
+// GameClient is the part of the API needed to run an engine search
+type GameClient interface {
+	CreateGame(req *CreateGameRequest) (*GameResponse, error)
+	GetGame(gameID string) (*GameResponse, error)
+	MakeMove(gameID string, move string) (*GameResponse, error)
+	DeleteGame(gameID string) error
+}
+
+// SearchPosition asks the server engine for its move in the position by
+// playing it in a throwaway game with the side to move as the computer.
+// The game is deleted afterwards. The server only sees the position, so
+// repetitions before it are unknown to the engine
+func SearchPosition(c GameClient, fen string, level int, searchTime time.Duration) (*MoveInfo, error) {
+	fields := strings.Fields(fen)
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("invalid FEN %q", fen)
+	}
+	turn, err := ParseColor(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid side to move in FEN %q", fen)
+	}
+
+	computer := PlayerConfig{Type: Computer, Level: level, SearchTime: int(searchTime.Milliseconds())}
+	req := &CreateGameRequest{
+		White: PlayerConfig{Type: Human},
+		Black: PlayerConfig{Type: Human},
+		FEN:   fen,
+	}
+	if turn == White {
+		req.White = computer
+	} else {
+		req.Black = computer
+	}
+
+	game, err := c.CreateGame(req)
+	if err != nil {
+		return nil, fmt.Errorf("create game: %w", err)
+	}
+	defer c.DeleteGame(game.GameID)
+
+	resp, err := c.MakeMove(game.GameID, "cccc")
+	if err != nil {
+		return nil, fmt.Errorf("trigger move: %w", err)
+	}
+	deadline := time.Now().Add(searchTime + searchSettle)
+	for resp.State == StatePending {
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("no move within %s", searchTime+searchSettle)
+		}
+		time.Sleep(searchPoll)
+		if resp, err = c.GetGame(game.GameID); err != nil {
+			return nil, fmt.Errorf("get game: %w", err)
+		}
+	}
+	if resp.LastMove == nil || len(resp.Moves) == 0 {
+		return nil, fmt.Errorf("server made no move, game is %s", resp.State)
+	}
+	return resp.LastMove, nil
+}
\ No newline at end of file
@@ -0,0 +1,150 @@
+// FILE: lixenwraith/chess/internal/client/api/stats.go
+package api
+
+import (
+	"math/rand"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxSamples bounds the latency samples kept per route; beyond it samples are
+// replaced by reservoir sampling so percentiles stay representative
+const maxSamples = 10000
+
+// RouteStats aggregates the calls made to one route template
+type RouteStats struct {
+	Route         string // method and template, e.g. "POST /api/v1/games/{id}/moves"
+	Count         int
+	Errors        int // transport failures and responses with status >= 400
+	Statuses      map[int]int
+	BytesSent     int64
+	BytesReceived int64
+	Latencies     []time.Duration // sampled, unordered
+}
+
+// Percentile returns the latency at percentile p (0-100) of the samples
+func (r *RouteStats) Percentile(p float64) time.Duration {
+	return Percentile(r.Latencies, p)
+}
+
+// Percentile returns the nearest-rank percentile p (0-100) of samples
+func Percentile(samples []time.Duration, p float64) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration{}, samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(p/100*float64(len(sorted))+0.5) - 1
+	rank = max(0, min(rank, len(sorted)-1))
+	return sorted[rank]
+}
+
+// RouteTemplate reduces a request path to its route, replacing game and
+// user identifiers with {id} and dropping the query string. Long polls keep
+// a "?wait" marker so their held-open latencies get a route of their own
+func RouteTemplate(path string) string {
+	var query url.Values
+	if u, err := url.Parse(path); err == nil {
+		path, query = u.Path, u.Query()
+	}
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		switch segments[i-1] {
+		case "games", "users":
+			if segments[i] != "" {
+				segments[i] = "{id}"
+			}
+		}
+	}
+	route := strings.Join(segments, "/")
+	if query.Has("wait") {
+		route += "?wait"
+	}
+	return route
+}
+
+// observe must be called with c.mu held
+func (c *Client) observe(ex *Exchange) {
+	route := ex.Method + " " + RouteTemplate(ex.URL)
+	rs, ok := c.stats[route]
+	if !ok {
+		if c.stats == nil {
+			c.stats = make(map[string]*RouteStats)
+		}
+		rs = &RouteStats{Route: route, Statuses: make(map[int]int)}
+		c.stats[route] = rs
+	}
+
+	rs.Count++
+	if ex.Err != nil || ex.Status >= 400 {
+		rs.Errors++
+	}
+	if ex.Status != 0 {
+		rs.Statuses[ex.Status]++
+	}
+	rs.BytesSent += int64(len(ex.RequestBody))
+	rs.BytesReceived += int64(len(ex.ResponseBody))
+
+	if len(rs.Latencies) < maxSamples {
+		rs.Latencies = append(rs.Latencies, ex.Timings.Total)
+	} else if i := rand.Intn(rs.Count); i < maxSamples {
+		rs.Latencies[i] = ex.Timings.Total
+	}
+}
+
+// Stats returns a snapshot of per-route statistics sorted by route
+func (c *Client) Stats() []RouteStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	out := make([]RouteStats, 0, len(c.stats))
+	for _, rs := range c.stats {
+		cp := *rs
+		cp.Statuses = make(map[int]int, len(rs.Statuses))
+		for k, v := range rs.Statuses {
+			cp.Statuses[k] = v
+		}
+		cp.Latencies = append([]time.Duration{}, rs.Latencies...)
+		out = append(out, cp)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Route < out[j].Route })
+	return out
+}
+
+// ResetStats discards all collected statistics
+func (c *Client) ResetStats() {
+	c.mu.Lock()
+	c.stats = nil
+	c.mu.Unlock()
+}
+
+// MergeStats combines statistics collected by several clients route by route
+func MergeStats(sets ...[]RouteStats) []RouteStats {
+	merged := make(map[string]*RouteStats)
+	for _, set := range sets {
+		for _, rs := range set {
+			m, ok := merged[rs.Route]
+			if !ok {
+				m = &RouteStats{Route: rs.Route, Statuses: make(map[int]int)}
+				merged[rs.Route] = m
+			}
+			m.Count += rs.Count
+			m.Errors += rs.Errors
+			m.BytesSent += rs.BytesSent
+			m.BytesReceived += rs.BytesReceived
+			for code, n := range rs.Statuses {
+				m.Statuses[code] += n
+			}
+			m.Latencies = append(m.Latencies, rs.Latencies...)
+		}
+	}
+
+	out := make([]RouteStats, 0, len(merged))
+	for _, m := range merged {
+		out = append(out, *m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Route < out[j].Route })
+	return out
+}
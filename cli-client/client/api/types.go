@@ -1,7 +1,313 @@
 // FILE: lixenwraith/chess/internal/client/api/types.go
 package api
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// PlayerType identifies who moves for a side
+type PlayerType int
+
+const (
+	Unknown  PlayerType = 0 // missing, or a type this client does not know
+	Human    PlayerType = 1
+	Computer PlayerType = 2
+)
+
+// ParsePlayerType accepts "h", "human", "c", "computer" and the numeric form
+func ParsePlayerType(s string) (PlayerType, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "h", "human", "1":
+		return Human, nil
+	case "c", "computer", "2":
+		return Computer, nil
+	}
+	return Unknown, fmt.Errorf("invalid player type: %q", s)
+}
+
+// Valid reports whether the type is human or computer
+func (p PlayerType) Valid() bool {
+	return p == Human || p == Computer
+}
+
+func (p PlayerType) String() string {
+	switch p {
+	case Human:
+		return "human"
+	case Computer:
+		return "computer"
+	case Unknown:
+		return "unknown"
+	}
+	return fmt.Sprintf("unknown(%d)", int(p))
+}
+
+// Short returns the one-letter form used in prompts: h, c or ?
+func (p PlayerType) Short() string {
+	switch p {
+	case Human:
+		return "h"
+	case Computer:
+		return "c"
+	}
+	return "?"
+}
+
+// MarshalJSON writes the numeric form, including invalid values so that
+// servers can be probed with them
+func (p PlayerType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int(p))
+}
+
+// UnmarshalJSON accepts the numeric or named form. A type the client does
+// not know decodes as Unknown rather than failing the whole response; the
+// response's Warnings report it
+func (p *PlayerType) UnmarshalJSON(data []byte) error {
+	var n int
+	if err := json.Unmarshal(data, &n); err == nil {
+		if *p = PlayerType(n); !p.Valid() {
+			*p = Unknown
+		}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid player type %s", data)
+	}
+	*p, _ = ParsePlayerType(s)
+	return nil
+}
+
+// Color is a side, in the server's one-letter form
+type Color string
+
+const (
+	NoColor Color = ""
+	White   Color = "w"
+	Black   Color = "b"
+)
+
+// ParseColor accepts "w", "white", "b" and "black"
+func ParseColor(s string) (Color, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "w", "white":
+		return White, nil
+	case "b", "black":
+		return Black, nil
+	}
+	return NoColor, fmt.Errorf("invalid color: %q", s)
+}
+
+// Valid reports whether the color is white or black
+func (c Color) Valid() bool {
+	return c == White || c == Black
+}
+
+// Other returns the opposing side
+func (c Color) Other() Color {
+	switch c {
+	case White:
+		return Black
+	case Black:
+		return White
+	}
+	return NoColor
+}
+
+// String returns "White", "Black", or the raw value for anything else
+func (c Color) String() string {
+	switch c {
+	case White:
+		return "White"
+	case Black:
+		return "Black"
+	}
+	return string(c)
+}
+
+// UnmarshalJSON accepts the one-letter or named form. An empty string or a
+// side the client does not know decodes as NoColor rather than failing the
+// whole response; the response's Warnings report an unknown one
+func (c *Color) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid color %s", data)
+	}
+	*c, _ = ParseColor(s)
+	return nil
+}
+
+// colorWarning reports a side the client does not know, "" for a known or
+// missing one
+func colorWarning(field, s string) string {
+	if _, err := ParseColor(s); s == "" || err == nil {
+		return ""
+	}
+	return fmt.Sprintf("unknown color %q for %s, treated as none", s, field)
+}
+
+// GameState is the lifecycle state of a game in its canonical spelling.
+// Decoding accepts every spelling servers are known to send; unrecognized
+// values are kept in normalized form so they can still be displayed
+type GameState string
+
+const (
+	StateOngoing   GameState = "ongoing"
+	StatePending   GameState = "pending" // computer move in progress
+	StateWhiteWins GameState = "white wins"
+	StateBlackWins GameState = "black wins"
+	StateStalemate GameState = "stalemate"
+	StateDraw      GameState = "draw"
+
+	// StateCheckmate is a mate reported without a winner; GameResponse
+	// resolves it to a win from the side to move
+	StateCheckmate GameState = "checkmate"
+)
+
+var stateSpellings = map[string]GameState{
+	"ongoing":     StateOngoing,
+	"active":      StateOngoing,
+	"in progress": StateOngoing,
+	"playing":     StateOngoing,
+	"pending":     StatePending,
+	"thinking":    StatePending,
+	"white wins":  StateWhiteWins,
+	"whitewins":   StateWhiteWins,
+	"white won":   StateWhiteWins,
+	"1 0":         StateWhiteWins,
+	"black wins":  StateBlackWins,
+	"blackwins":   StateBlackWins,
+	"black won":   StateBlackWins,
+	"0 1":         StateBlackWins,
+	"checkmate":   StateCheckmate,
+	"mate":        StateCheckmate,
+	"stalemate":   StateStalemate,
+	"draw":        StateDraw,
+	"drawn":       StateDraw,
+	"1/2 1/2":     StateDraw,
+}
+
+// ParseGameState normalizes a server spelling. A bare "checkmate" is
+// resolved against turn, the side to move and therefore the mated side, and
+// left as StateCheckmate when turn is unknown
+func ParseGameState(s string, turn Color) GameState {
+	key := strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return unicode.IsSpace(r) || r == '_' || r == '-'
+	}), " ")
+	state, ok := stateSpellings[key]
+	if !ok {
+		return GameState(key)
+	}
+	if state == StateCheckmate {
+		switch turn {
+		case White:
+			return StateBlackWins
+		case Black:
+			return StateWhiteWins
+		}
+	}
+	return state
+}
+
+// Known reports whether the state is part of the canonical vocabulary
+func (g GameState) Known() bool {
+	switch g {
+	case StateOngoing, StatePending, StateWhiteWins, StateBlackWins, StateCheckmate, StateStalemate, StateDraw:
+		return true
+	}
+	return false
+}
+
+// IsOver reports whether the game has ended
+func (g GameState) IsOver() bool {
+	switch g {
+	case StateWhiteWins, StateBlackWins, StateCheckmate, StateStalemate, StateDraw:
+		return true
+	}
+	return false
+}
+
+// Winner returns the winning side of a decided game, NoColor otherwise
+func (g GameState) Winner() Color {
+	switch g {
+	case StateWhiteWins:
+		return White
+	case StateBlackWins:
+		return Black
+	}
+	return NoColor
+}
+
+// Result returns the PGN result token, "*" while the game is undecided
+func (g GameState) Result() string {
+	switch g {
+	case StateWhiteWins:
+		return "1-0"
+	case StateBlackWins:
+		return "0-1"
+	case StateStalemate, StateDraw:
+		return "1/2-1/2"
+	}
+	return "*"
+}
+
+// Reason describes the state for display
+func (g GameState) Reason() string {
+	switch g {
+	case StateOngoing:
+		return "Game in progress"
+	case StatePending:
+		return "Computer is thinking"
+	case StateWhiteWins:
+		return "Checkmate! White wins"
+	case StateBlackWins:
+		return "Checkmate! Black wins"
+	case StateCheckmate:
+		return "Checkmate!"
+	case StateStalemate:
+		return "Stalemate! Game drawn"
+	case StateDraw:
+		return "Draw! Game drawn"
+	}
+	return fmt.Sprintf("Unknown state %q", string(g))
+}
+
+// Termination is how a game ended when the state alone does not tell, e.g.
+// by resignation, a claimed draw or a draw rule applied by the server
+type Termination string
+
+const (
+	TermResignation  Termination = "resignation"
+	TermAgreement    Termination = "agreement"
+	TermRepetition   Termination = "threefold repetition"
+	TermFiftyMoves   Termination = "fifty-move rule"
+	TermFivefold     Termination = "fivefold repetition"
+	TermSeventyFive  Termination = "seventy-five-move rule"
+	TermInsufficient Termination = "insufficient material"
+)
+
+// DrawAction is one step of settling a game as a draw
+type DrawAction string
+
+const (
+	DrawOffer   DrawAction = "offer"
+	DrawAccept  DrawAction = "accept"
+	DrawDecline DrawAction = "decline"
+	DrawClaim   DrawAction = "claim"
+)
+
+func (g *GameState) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*g = ParseGameState(s, "")
+	return nil
+}
 
 // Request types
 type CreateGameRequest struct {
@@ -11,9 +317,9 @@ type CreateGameRequest struct {
 }
 
 type PlayerConfig struct {
-	Type       int `json:"type"` // 1=human, 2=computer
-	Level      int `json:"level,omitempty"`
-	SearchTime int `json:"searchTime,omitempty"`
+	Type       PlayerType `json:"type"`
+	Level      int        `json:"level,omitempty"`
+	SearchTime int        `json:"searchTime,omitempty"`
 }
 
 type MoveRequest struct {
@@ -24,12 +330,34 @@ type UndoRequest struct {
 	Count int `json:"count"`
 }
 
+// ResignRequest names the side giving up the game
+type ResignRequest struct {
+	Color Color `json:"color"`
+}
+
+// DrawRequest is a draw action by one side; Reason names the rule a claim
+// is made under
+type DrawRequest struct {
+	Action DrawAction  `json:"action"`
+	Color  Color       `json:"color"`
+	Reason Termination `json:"reason,omitempty"`
+}
+
 type RegisterRequest struct {
 	Username string `json:"username"`
 	Email    string `json:"email,omitempty"`
 	Password string `json:"password"`
 }
 
+// ListGamesQuery filters and pages a game listing; zero values match all
+type ListGamesQuery struct {
+	State    string     // "ongoing" or "finished"
+	Mine     bool       // only games the authenticated user plays in
+	Opponent PlayerType // only games with a player of this type
+	Limit    int
+	Offset   int
+}
+
 type LoginRequest struct {
 	Identifier string `json:"identifier"`
 	Password   string `json:"password"`
@@ -39,11 +367,59 @@ type LoginRequest struct {
 type GameResponse struct {
 	GameID   string          `json:"gameId"`
 	FEN      string          `json:"fen"`
-	Turn     string          `json:"turn"`
-	State    string          `json:"state"`
+	Turn     Color           `json:"turn"`
+	State    GameState       `json:"state"`
 	Moves    []string        `json:"moves"`
 	Players  PlayersResponse `json:"players"`
 	LastMove *MoveInfo       `json:"lastMove,omitempty"`
+	// Side with a draw offer standing, NoColor if none
+	DrawOffer   Color       `json:"drawOffer,omitempty"`
+	Termination Termination `json:"termination,omitempty"`
+
+	warnings []string // colors normalization could not map
+}
+
+// Reason describes the game's state, including terminations off the board
+func (g *GameResponse) Reason() string {
+	switch g.Termination {
+	case TermResignation:
+		if winner := g.State.Winner(); winner.Valid() {
+			return fmt.Sprintf("%s resigns, %s wins", winner.Other(), winner)
+		}
+	case TermAgreement, TermRepetition, TermFiftyMoves, TermFivefold, TermSeventyFive, TermInsufficient:
+		return "Draw by " + string(g.Termination)
+	}
+	return g.State.Reason()
+}
+
+// UnmarshalJSON decodes the game and resolves a winnerless checkmate from
+// the side to move
+func (g *GameResponse) UnmarshalJSON(data []byte) error {
+	type plain GameResponse
+	aux := struct {
+		*plain
+		Turn      string `json:"turn"`
+		State     string `json:"state"`
+		DrawOffer string `json:"drawOffer"`
+	}{plain: (*plain)(g)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	g.Turn, _ = ParseColor(aux.Turn)
+	g.DrawOffer, _ = ParseColor(aux.DrawOffer)
+	g.warnings = nil
+	for _, w := range []string{colorWarning("the side to move", aux.Turn), colorWarning("the draw offer", aux.DrawOffer)} {
+		if w != "" {
+			g.warnings = append(g.warnings, w)
+		}
+	}
+	g.State = ParseGameState(aux.State, g.Turn)
+	return nil
+}
+
+// Warnings reports values normalization could not map
+func (g *GameResponse) Warnings() []string {
+	return append(g.Players.Warnings(), g.warnings...)
 }
 
 type PlayersResponse struct {
@@ -51,20 +427,94 @@ type PlayersResponse struct {
 	Black PlayerInfo `json:"black"`
 }
 
+// Warnings lists the sides whose player type was not recognised
+func (p PlayersResponse) Warnings() []string {
+	var warnings []string
+	for _, side := range []struct {
+		color Color
+		info  PlayerInfo
+	}{{White, p.White}, {Black, p.Black}} {
+		if !side.info.Type.Valid() {
+			warnings = append(warnings, fmt.Sprintf("unknown player type for %s, treated as neither human nor computer", side.color))
+		}
+	}
+	return warnings
+}
+
+// Player returns the player for the given side
+func (p PlayersResponse) Player(c Color) PlayerInfo {
+	if c == Black {
+		return p.Black
+	}
+	return p.White
+}
+
 type PlayerInfo struct {
-	ID         string `json:"id"`
-	Type       int    `json:"type"`
-	Level      int    `json:"level,omitempty"`
-	SearchTime int    `json:"searchTime,omitempty"`
+	ID         string     `json:"id"`
+	Type       PlayerType `json:"type"`
+	Level      int        `json:"level,omitempty"`
+	SearchTime int        `json:"searchTime,omitempty"`
 }
 
 type MoveInfo struct {
 	Move        string `json:"move"`
-	PlayerColor string `json:"playerColor"`
+	PlayerColor Color  `json:"playerColor"`
 	Score       int    `json:"score,omitempty"`
 	Depth       int    `json:"depth,omitempty"`
 }
 
+// GameSummary is one entry of a game listing
+type GameSummary struct {
+	GameID    string          `json:"gameId"`
+	Turn      Color           `json:"turn"`
+	State     GameState       `json:"state"`
+	MoveCount int             `json:"moveCount"`
+	Players   PlayersResponse `json:"players"`
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+
+	warnings []string // colors normalization could not map
+}
+
+// UnmarshalJSON decodes the summary and resolves a winnerless checkmate
+// from the side to move, as GameResponse does
+func (g *GameSummary) UnmarshalJSON(data []byte) error {
+	type plain GameSummary
+	aux := struct {
+		*plain
+		Turn  string `json:"turn"`
+		State string `json:"state"`
+	}{plain: (*plain)(g)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	g.Turn, _ = ParseColor(aux.Turn)
+	g.warnings = nil
+	if w := colorWarning("the side to move", aux.Turn); w != "" {
+		g.warnings = []string{w}
+	}
+	g.State = ParseGameState(aux.State, g.Turn)
+	return nil
+}
+
+type GameListResponse struct {
+	Games  []GameSummary `json:"games"`
+	Total  int           `json:"total"`
+	Offset int           `json:"offset"`
+	Limit  int           `json:"limit"`
+}
+
+// Warnings reports values normalization could not map, per game
+func (l *GameListResponse) Warnings() []string {
+	var warnings []string
+	for _, g := range l.Games {
+		for _, w := range append(g.Players.Warnings(), g.warnings...) {
+			warnings = append(warnings, g.GameID+": "+w)
+		}
+	}
+	return warnings
+}
+
 type BoardResponse struct {
 	FEN   string `json:"fen"`
 	Board string `json:"board"`
@@ -90,6 +540,18 @@ type ErrorResponse struct {
 	Details string `json:"details,omitempty"`
 }
 
+// APIError is returned for responses with status >= 400; Response is nil
+// when the body is not a valid ErrorResponse
+type APIError struct {
+	Status   int
+	Response *ErrorResponse
+	Body     []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("request failed with status %d", e.Status)
+}
+
 type HealthResponse struct {
 	Status  string `json:"status"`
 	Time    int64  `json:"time"`
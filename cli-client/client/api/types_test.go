@@ -0,0 +1,136 @@
+// FILE: lixenwraith/chess/internal/client/api/types_test.go
+package api_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"chess/internal/client/api"
+)
+
+func TestUnknownPlayerType(t *testing.T) {
+	cases := []struct {
+		name  string
+		white string
+		black string
+		want  [2]api.PlayerType
+		warns int
+	}{
+		{"known", `1`, `"computer"`, [2]api.PlayerType{api.Human, api.Computer}, 0},
+		{"unknown number", `7`, `2`, [2]api.PlayerType{api.Unknown, api.Computer}, 1},
+		{"unknown name", `"robot"`, `"h"`, [2]api.PlayerType{api.Unknown, api.Human}, 1},
+		{"both unknown", `0`, `"spectator"`, [2]api.PlayerType{api.Unknown, api.Unknown}, 2},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := `{"gameId":"g1","turn":"w","state":"ongoing","moves":["e2e4"],` +
+				`"players":{"white":{"type":` + tc.white + `},"black":{"type":` + tc.black + `,"level":3}}}`
+			var game api.GameResponse
+			if err := json.Unmarshal([]byte(data), &game); err != nil {
+				t.Fatalf("decode failed: %v", err)
+			}
+			if got := [2]api.PlayerType{game.Players.White.Type, game.Players.Black.Type}; got != tc.want {
+				t.Fatalf("types = %v, want %v", got, tc.want)
+			}
+			if game.GameID != "g1" || game.State != api.StateOngoing || len(game.Moves) != 1 || game.Players.Black.Level != 3 {
+				t.Fatalf("rest of the response lost: %+v", game)
+			}
+			if warnings := game.Warnings(); len(warnings) != tc.warns {
+				t.Fatalf("warnings = %q, want %d", warnings, tc.warns)
+			}
+		})
+	}
+
+	var p api.PlayerType
+	if err := json.Unmarshal([]byte(`{}`), &p); err == nil {
+		t.Fatal("malformed type decoded without error")
+	}
+}
+
+func TestParseGameState(t *testing.T) {
+	cases := []struct {
+		in   string
+		turn api.Color
+		want api.GameState
+	}{
+		{"ongoing", api.White, api.StateOngoing},
+		{"In_Progress", api.White, api.StateOngoing},
+		{"  white   wins ", api.Black, api.StateWhiteWins},
+		{"Black-Wins", api.White, api.StateBlackWins},
+		{"1-0", api.Black, api.StateWhiteWins},
+		{"1/2-1/2", api.White, api.StateDraw},
+		{"thinking", api.Black, api.StatePending},
+		{"checkmate", api.White, api.StateBlackWins},
+		{"MATE", api.Black, api.StateWhiteWins},
+		{"checkmate", api.NoColor, api.StateCheckmate},
+		{"Time\tForfeit", api.White, api.GameState("time forfeit")},
+	}
+	for _, tc := range cases {
+		if got := api.ParseGameState(tc.in, tc.turn); got != tc.want {
+			t.Errorf("ParseGameState(%q, %q) = %q, want %q", tc.in, tc.turn, got, tc.want)
+		}
+	}
+}
+
+func TestGameSummaryCheckmate(t *testing.T) {
+	data := `{"games":[{"gameId":"g1","turn":"b","state":"checkmate"},` +
+		`{"gameId":"g2","state":"checkmate"},{"gameId":"g3","turn":"w","state":"Black_Wins","moveCount":4}]}`
+	var list api.GameListResponse
+	if err := json.Unmarshal([]byte(data), &list); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	want := []api.GameState{api.StateWhiteWins, api.StateCheckmate, api.StateBlackWins}
+	for i, g := range list.Games {
+		if g.State != want[i] {
+			t.Errorf("%s: state %q, want %q", g.GameID, g.State, want[i])
+		}
+	}
+	if list.Games[2].MoveCount != 4 || list.Games[0].Turn != api.Black {
+		t.Fatalf("rest of the summary lost: %+v", list.Games)
+	}
+}
+
+func TestUnknownColor(t *testing.T) {
+	cases := []struct {
+		name      string
+		turn      string
+		drawOffer string
+		want      [2]api.Color
+		warns     int
+	}{
+		{"known", `"w"`, `"black"`, [2]api.Color{api.White, api.Black}, 0},
+		{"missing", `""`, `""`, [2]api.Color{api.NoColor, api.NoColor}, 0},
+		{"unknown turn", `"red"`, `""`, [2]api.Color{api.NoColor, api.NoColor}, 1},
+		{"both unknown", `"x"`, `"none"`, [2]api.Color{api.NoColor, api.NoColor}, 2},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := `{"gameId":"g1","turn":` + tc.turn + `,"state":"checkmate","drawOffer":` + tc.drawOffer +
+				`,"players":{"white":{"type":1},"black":{"type":1}}}`
+			var game api.GameResponse
+			if err := json.Unmarshal([]byte(data), &game); err != nil {
+				t.Fatalf("decode failed: %v", err)
+			}
+			if got := [2]api.Color{game.Turn, game.DrawOffer}; got != tc.want {
+				t.Fatalf("colors = %v, want %v", got, tc.want)
+			}
+			if warnings := game.Warnings(); len(warnings) != tc.warns {
+				t.Fatalf("warnings = %q, want %d", warnings, tc.warns)
+			}
+		})
+	}
+
+	var list api.GameListResponse
+	if err := json.Unmarshal([]byte(`{"games":[{"gameId":"g1","turn":"red","state":"ongoing",`+
+		`"players":{"white":{"type":1},"black":{"type":2}}}]}`), &list); err != nil {
+		t.Fatalf("list decode failed: %v", err)
+	}
+	if warnings := list.Warnings(); list.Games[0].Turn != api.NoColor || len(warnings) != 1 {
+		t.Fatalf("turn %q, warnings %q", list.Games[0].Turn, warnings)
+	}
+
+	var c api.Color
+	if err := json.Unmarshal([]byte(`1`), &c); err == nil {
+		t.Fatal("malformed color decoded without error")
+	}
+}
\ No newline at end of file
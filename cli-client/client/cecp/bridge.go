@@ -0,0 +1,417 @@
+// FILE: lixenwraith/chess/internal/client/cecp/bridge.go
+// Package cecp speaks the Chess Engine Communication Protocol so the chess
+// server can be played through XBoard, WinBoard and compatible interfaces.
+package cecp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/engine"
+	"chess/internal/client/rules"
+)
+
+const (
+	// defaultMovesToGo spreads the remaining clock when the time control
+	// has no move count
+	defaultMovesToGo = 30
+
+	settleMargin = 5 * time.Second
+	pollInterval = 100 * time.Millisecond
+)
+
+// Bridge keeps the game played through the interface and mirrors it on the
+// server, where the side the engine plays is the server computer. The local
+// game is authoritative: when the server game cannot follow it, for example
+// after the engine changes sides, it is replaced by a new game starting
+// from the current position. The server fixes the computer's level and
+// search time when a game is created, so a changed level or time budget
+// replaces the game too
+type Bridge struct {
+	client    *api.Client
+	baseLevel int // level restored by 'new'
+	level     int
+	out       io.Writer
+
+	history *rules.History // game from its start position
+	moves   []string
+	engine  api.Color // side the engine plays, NoColor in force mode
+	over    bool      // game ended on the board or by 'result'
+
+	// Time control
+	moveTime time.Duration // fixed time per move from 'st', 0 to use the clock
+	mps      int           // moves per time control, 0 for incremental
+	inc      time.Duration
+	clock    time.Duration // engine's remaining time from 'time'
+
+	// Server mirror of the game, gameID "" when there is none
+	gameID       string
+	gameBase     int       // ply of the local game the server game starts at
+	gameComputer api.Color // side the server computer plays
+	gameLevel    int
+	gameBudget   time.Duration // search time the server game was created with
+}
+
+// NewBridge returns a bridge whose server engine plays at the given level
+func NewBridge(client *api.Client, level int) *Bridge {
+	level = max(0, min(level, engine.MaxLevel))
+	b := &Bridge{
+		client:    client,
+		baseLevel: level,
+		level:     level,
+	}
+	b.reset(rules.Start())
+	return b
+}
+
+// Run reads CECP commands from in and writes responses to out until 'quit'
+// or the end of input
+func (b *Bridge) Run(in io.Reader, out io.Writer) error {
+	b.out = out
+	defer b.dropGame()
+
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch cmd, args := fields[0], fields[1:]; cmd {
+		case "xboard", "accepted", "rejected", "random", "hard", "easy", "post", "nopost", "computer", "otim", "?":
+			// Nothing to do
+
+		case "protover":
+			b.send(`feature myname="chess-xboard" usermove=1 setboard=1 ping=1 playother=1 colors=0 sigint=0 sigterm=0 done=1`)
+
+		case "ping":
+			b.send("pong %s", strings.Join(args, " "))
+
+		case "new":
+			b.reset(rules.Start())
+			b.engine = api.Black
+			b.level = b.baseLevel
+
+		case "force":
+			b.engine = api.NoColor
+
+		case "go":
+			b.engine = api.Color(b.position().Turn)
+			b.think()
+
+		case "playother":
+			b.engine = api.Color(b.position().Turn.Other())
+
+		case "usermove":
+			if len(args) != 1 {
+				b.send("Error (usermove needs one move): %s", line)
+				continue
+			}
+			b.userMove(args[0])
+
+		case "setboard":
+			pos, err := rules.CheckFEN(strings.Join(args, " "))
+			if err != nil {
+				b.send("tellusererror Illegal position: %s", err.Error())
+				continue
+			}
+			b.reset(pos)
+
+		case "undo":
+			b.takeBack(1)
+
+		case "remove":
+			b.takeBack(2)
+
+		case "level":
+			if err := b.setLevel(args); err != nil {
+				b.send("Error (%s): %s", err.Error(), line)
+			}
+
+		case "st":
+			seconds, err := strconv.Atoi(strings.Join(args, ""))
+			if err != nil || seconds < 1 {
+				b.send("Error (bad time): %s", line)
+				continue
+			}
+			b.moveTime = time.Duration(seconds) * time.Second
+
+		case "sd":
+			depth, err := strconv.Atoi(strings.Join(args, ""))
+			if err != nil || depth < 1 {
+				b.send("Error (bad depth): %s", line)
+				continue
+			}
+			// Weakest level whose depth limit reaches the requested depth
+			b.level = 0
+			for b.level < engine.MaxLevel && engine.MaxDepth(b.level) < depth {
+				b.level++
+			}
+
+		case "time":
+			if cs, err := strconv.Atoi(strings.Join(args, "")); err == nil {
+				b.clock = time.Duration(cs) * 10 * time.Millisecond
+			}
+
+		case "result":
+			b.over = true
+			b.engine = api.NoColor
+			b.dropGame()
+
+		case "quit":
+			return nil
+
+		default:
+			// Older interfaces send moves without the usermove prefix
+			if len(args) == 0 {
+				if _, err := b.position().ParseMove(cmd); err == nil {
+					b.userMove(cmd)
+					continue
+				}
+			}
+			b.send("Error (unknown command): %s", cmd)
+		}
+	}
+	return scanner.Err()
+}
+
+func (b *Bridge) send(format string, args ...any) {
+	fmt.Fprintf(b.out, format+"\n", args...)
+}
+
+func (b *Bridge) position() *rules.Position {
+	return b.history.Position()
+}
+
+// reset starts a new local game from pos and drops the server game
+func (b *Bridge) reset(pos *rules.Position) {
+	b.dropGame()
+	b.history = rules.NewHistory(pos)
+	b.moves = nil
+	b.over = false
+}
+
+// userMove plays the interface user's move and lets the engine answer
+func (b *Bridge) userMove(move string) {
+	pos := b.position()
+	m, err := pos.ParseMove(move)
+	if err != nil || b.over {
+		b.send("Illegal move: %s", move)
+		return
+	}
+
+	// Mirror the move when the server game has a human on that side
+	mover := api.Color(pos.Turn)
+	if b.gameID == "" && b.engine.Valid() && b.engine != mover {
+		b.ensureGame(b.engine)
+	}
+	if b.gameID != "" {
+		if b.gameComputer == mover {
+			b.dropGame()
+		} else if _, err := b.client.MakeMove(b.gameID, m.String()); err != nil {
+			b.send("telluser server rejected %s: %s", m, err.Error())
+			b.dropGame()
+		}
+	}
+
+	b.play(m)
+	if !b.over && b.engine == api.Color(b.position().Turn) {
+		b.think()
+	}
+}
+
+// think has the server computer move for the side to move
+func (b *Bridge) think() {
+	if b.over {
+		return
+	}
+	pos := b.position()
+	if len(pos.LegalMoves()) == 0 {
+		return
+	}
+
+	move, err := b.serverMove()
+	if err != nil {
+		// The interface waits for a move, fall back to the first legal one
+		b.send("tellusererror Server move failed: %s", err.Error())
+		b.dropGame()
+		move = pos.LegalMoves()[0]
+	}
+	b.send("move %s", move)
+	b.play(move)
+}
+
+// serverMove triggers the computer move in the server game and returns it
+func (b *Bridge) serverMove() (rules.Move, error) {
+	turn := api.Color(b.position().Turn)
+	if err := b.ensureGame(turn); err != nil {
+		return rules.Move{}, err
+	}
+
+	resp, err := b.client.MakeMove(b.gameID, "cccc")
+	if err != nil {
+		return rules.Move{}, fmt.Errorf("trigger move: %w", err)
+	}
+	wait := b.budget() + settleMargin
+	deadline := time.Now().Add(wait)
+	for resp.State == api.StatePending {
+		if time.Now().After(deadline) {
+			return rules.Move{}, fmt.Errorf("no move within %s", wait)
+		}
+		time.Sleep(pollInterval)
+		if resp, err = b.client.GetGame(b.gameID); err != nil {
+			return rules.Move{}, fmt.Errorf("get game: %w", err)
+		}
+	}
+	if resp.LastMove == nil || len(resp.Moves) != len(b.moves)-b.gameBase+1 {
+		return rules.Move{}, fmt.Errorf("server made no move, game is %s", resp.State)
+	}
+	return b.position().ParseMove(resp.LastMove.Move)
+}
+
+// ensureGame makes sure a server game mirrors the local one with the
+// computer playing the given side at the current level and time budget,
+// creating it from the current position
+func (b *Bridge) ensureGame(computer api.Color) error {
+	budget := b.budget()
+	if b.gameID != "" && b.gameComputer == computer && b.gameLevel == b.level && b.gameBudget == budget {
+		return nil
+	}
+	b.dropGame()
+
+	cfg := api.PlayerConfig{Type: api.Computer, Level: b.level, SearchTime: int(budget.Milliseconds())}
+	req := &api.CreateGameRequest{
+		White: api.PlayerConfig{Type: api.Human},
+		Black: api.PlayerConfig{Type: api.Human},
+		FEN:   b.position().FEN(),
+	}
+	if computer == api.White {
+		req.White = cfg
+	} else {
+		req.Black = cfg
+	}
+	game, err := b.client.CreateGame(req)
+	if err != nil {
+		return fmt.Errorf("create game: %w", err)
+	}
+	b.gameID, b.gameBase, b.gameComputer = game.GameID, len(b.moves), computer
+	b.gameLevel, b.gameBudget = b.level, budget
+	return nil
+}
+
+// dropGame deletes the server game, a new one is created when needed
+func (b *Bridge) dropGame() {
+	if b.gameID != "" {
+		b.client.DeleteGame(b.gameID)
+		b.gameID = ""
+	}
+}
+
+// takeBack retracts plies locally and on the server
+func (b *Bridge) takeBack(count int) {
+	if count > len(b.moves) {
+		b.send("Error (no moves to undo): undo")
+		return
+	}
+	if b.gameID != "" {
+		if len(b.moves)-count < b.gameBase {
+			b.dropGame()
+		} else if _, err := b.client.UndoMoves(b.gameID, count); err != nil {
+			b.dropGame()
+		}
+	}
+	b.history.Undo(count)
+	b.moves = b.moves[:len(b.moves)-count]
+	b.over = false
+}
+
+// play applies a legal move locally and reports the result if it ends the game
+func (b *Bridge) play(m rules.Move) {
+	b.history.Play(m)
+	b.moves = append(b.moves, m.String())
+	if result := b.result(); result != "" {
+		b.over = true
+		b.send("%s", result)
+	}
+}
+
+// result returns the CECP result line when the game is over, "" otherwise
+func (b *Bridge) result() string {
+	pos := b.position()
+	switch pos.Status() {
+	case rules.Checkmate:
+		if pos.Turn == rules.White {
+			return "0-1 {Black mates}"
+		}
+		return "1-0 {White mates}"
+	case rules.Stalemate:
+		return "1/2-1/2 {Stalemate}"
+	case rules.InsufficientMaterial:
+		return "1/2-1/2 {Insufficient material}"
+	}
+
+	// The bridge claims the draws a claim is needed for
+	if pos.Halfmove >= rules.ClaimPlies {
+		return "1/2-1/2 {Draw by fifty move rule}"
+	}
+
+	if b.history.Repetitions() >= 3 {
+		return "1/2-1/2 {Draw by repetition}"
+	}
+	return ""
+}
+
+// setLevel handles "level MPS BASE INC" with BASE in minutes or minutes:seconds
+func (b *Bridge) setLevel(args []string) error {
+	if len(args) != 3 {
+		return fmt.Errorf("level needs MPS BASE INC")
+	}
+	mps, err := strconv.Atoi(args[0])
+	if err != nil || mps < 0 {
+		return fmt.Errorf("bad moves per session")
+	}
+	minutes, seconds, _ := strings.Cut(args[1], ":")
+	m, err1 := strconv.Atoi(minutes)
+	s := 0
+	var err2 error
+	if seconds != "" {
+		s, err2 = strconv.Atoi(seconds)
+	}
+	if err1 != nil || err2 != nil || m < 0 || s < 0 {
+		return fmt.Errorf("bad base time")
+	}
+	inc, err := strconv.ParseFloat(args[2], 64)
+	if err != nil || inc < 0 {
+		return fmt.Errorf("bad increment")
+	}
+
+	b.mps = mps
+	b.inc = time.Duration(inc * float64(time.Second))
+	b.clock = time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+	b.moveTime = 0
+	return nil
+}
+
+// budget is the server search time for the next engine move
+func (b *Bridge) budget() time.Duration {
+	var t time.Duration
+	switch {
+	case b.moveTime > 0:
+		t = b.moveTime
+	case b.clock > 0:
+		movesToGo := defaultMovesToGo
+		if b.mps > 0 {
+			movesToGo = b.mps - (len(b.moves)/2)%b.mps
+		}
+		t = min(b.clock/time.Duration(movesToGo)+b.inc, b.clock/2)
+	default:
+		t = engine.DefaultSearchTime
+	}
+	return max(api.MinSearchTime, min(t, api.MaxSearchTime))
+}
\ No newline at end of file
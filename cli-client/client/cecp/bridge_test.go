@@ -0,0 +1,136 @@
+// FILE: lixenwraith/chess/internal/client/cecp/bridge_test.go
+package cecp
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"chess/internal/client/api"
+	"chess/internal/client/clienttest"
+	"chess/internal/client/engine"
+)
+
+// runBridge plays the CECP lines against a fake server and returns the
+// bridge output and the games it created there
+func runBridge(t *testing.T, replies []string, lines ...string) (string, []api.CreateGameRequest) {
+	t.Helper()
+	fake, srv := clienttest.NewServer()
+	defer srv.Close()
+	fake.QueueComputerMoves(replies...)
+
+	client := api.New(srv.URL)
+	client.SetQuiet(true)
+	var out strings.Builder
+	if err := NewBridge(client, 3).Run(strings.NewReader(strings.Join(lines, "\n")+"\n"), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	var created []api.CreateGameRequest
+	for _, ex := range client.History() {
+		if ex.Method != http.MethodPost || !strings.HasSuffix(ex.URL, "/api/v1/games") {
+			continue
+		}
+		var req api.CreateGameRequest
+		if err := json.Unmarshal([]byte(ex.RequestBody), &req); err != nil {
+			t.Fatal(err)
+		}
+		created = append(created, req)
+	}
+	if fake.GameCount() != 0 {
+		t.Errorf("%d server games left behind", fake.GameCount())
+	}
+	return out.String(), created
+}
+
+func TestBridge(t *testing.T) {
+	// depthLevel is the level 'sd 1' selects
+	depthLevel := 0
+	for depthLevel < engine.MaxLevel && engine.MaxDepth(depthLevel) < 1 {
+		depthLevel++
+	}
+
+	cases := []struct {
+		name    string
+		replies []string
+		lines   []string
+		want    []string // output lines expected, in order
+		search  []int    // search times of the games created, in ms
+		levels  []int    // levels of the games created
+	}{
+		{
+			name:    "engine plays white after go",
+			replies: []string{"e2e4"},
+			lines:   []string{"xboard", "protover 2", "new", "st 2", "go"},
+			want:    []string{"feature myname=", "move e2e4"},
+			search:  []int{2000},
+			levels:  []int{3},
+		},
+		{
+			name:    "same budget keeps the game",
+			replies: []string{"e7e5", "b8c6"},
+			lines:   []string{"new", "st 2", "usermove e2e4", "usermove g1f3"},
+			want:    []string{"move e7e5", "move b8c6"},
+			search:  []int{2000},
+			levels:  []int{3},
+		},
+		{
+			name:    "shrinking clock recreates the game",
+			replies: []string{"e7e5", "b8c6"},
+			lines:   []string{"new", "level 40 5 0", "time 30000", "otim 30000", "usermove e2e4", "time 1000", "otim 29000", "usermove g1f3"},
+			want:    []string{"move e7e5", "move b8c6"},
+			search:  []int{7500, 256},
+			levels:  []int{3, 3},
+		},
+		{
+			name:    "depth limit changes the level",
+			replies: []string{"e7e5", "b8c6"},
+			lines:   []string{"new", "st 1", "usermove e2e4", "sd 1", "usermove g1f3"},
+			want:    []string{"move e7e5", "move b8c6"},
+			search:  []int{1000, 1000},
+			levels:  []int{3, depthLevel},
+		},
+		{
+			name:    "new restores the level",
+			replies: []string{"e7e5", "e7e5"},
+			lines:   []string{"new", "st 1", "sd 1", "usermove e2e4", "new", "st 1", "usermove e2e4"},
+			want:    []string{"move e7e5", "move e7e5"},
+			search:  []int{1000, 1000},
+			levels:  []int{depthLevel, 3},
+		},
+		{
+			name:  "bad commands",
+			lines: []string{"level 40", "st soon", "sd 0", "usermove", "usermove e2e5", "frobnicate"},
+			want:  []string{"Error (level needs MPS BASE INC)", "Error (bad time)", "Error (bad depth)", "Error (usermove needs one move)", "Illegal move: e2e5", "Error (unknown command): frobnicate"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, created := runBridge(t, tc.replies, tc.lines...)
+			rest := out
+			for _, w := range tc.want {
+				i := strings.Index(rest, w)
+				if i < 0 {
+					t.Fatalf("output lacks %q in order:\n%s", w, out)
+				}
+				rest = rest[i+len(w):]
+			}
+
+			var search, levels []int
+			for _, req := range created {
+				computer := req.Black
+				if req.White.Type == api.Computer {
+					computer = req.White
+				}
+				search = append(search, computer.SearchTime)
+				levels = append(levels, computer.Level)
+			}
+			if !reflect.DeepEqual(search, tc.search) || !reflect.DeepEqual(levels, tc.levels) {
+				t.Fatalf("created games with search times %v and levels %v, want %v and %v", search, levels, tc.search, tc.levels)
+			}
+		})
+	}
+}
\ No newline at end of file
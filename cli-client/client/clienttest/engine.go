@@ -0,0 +1,26 @@
+// FILE: lixenwraith/chess/internal/client/clienttest/engine.go
+package clienttest
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// FakeEnginePackage is the scripted UCI engine built by FakeEngine
+const FakeEnginePackage = "chess/cmd/chess-fake-uci"
+
+// FakeEngine builds the scripted UCI engine into a temporary directory and
+// returns the path of the binary. The test is skipped without a Go toolchain
+func FakeEngine(t testing.TB) string {
+	t.Helper()
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not found, cannot build the fake engine")
+	}
+	bin := filepath.Join(t.TempDir(), "chess-fake-uci")
+	if out, err := exec.Command(goTool, "build", "-o", bin, FakeEnginePackage).CombinedOutput(); err != nil {
+		t.Fatalf("build %s: %v\n%s", FakeEnginePackage, err, out)
+	}
+	return bin
+}
\ No newline at end of file
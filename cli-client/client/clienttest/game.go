@@ -0,0 +1,190 @@
+// FILE: lixenwraith/chess/internal/client/clienttest/game.go
+package clienttest
+
+import (
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/rules"
+)
+
+type game struct {
+	id       string
+	startFEN string
+	pos      *rules.Position
+	state    api.GameState
+	moves    []string
+	white    api.PlayerInfo
+	black    api.PlayerInfo
+	lastMove *api.MoveInfo
+	created  time.Time
+	updated  time.Time
+	changed  chan struct{} // closed and replaced on every change
+
+	drawOffer   api.Color // side with a standing draw offer
+	termination api.Termination
+}
+
+func newPlayer(cfg api.PlayerConfig, userID *string) api.PlayerInfo {
+	p := api.PlayerInfo{
+		Type:       cfg.Type,
+		Level:      cfg.Level,
+		SearchTime: cfg.SearchTime,
+	}
+	if cfg.Type == api.Human && *userID != "" {
+		p.ID = *userID
+		*userID = ""
+	} else {
+		p.ID = newID()
+	}
+	return p
+}
+
+func (g *game) playerToMove() api.PlayerInfo {
+	return g.player(api.Color(g.pos.Turn))
+}
+
+func (g *game) player(c api.Color) api.PlayerInfo {
+	if c == api.Black {
+		return g.black
+	}
+	return g.white
+}
+
+// play applies a legal move, updates the game state and wakes long-poll
+// waiters
+func (g *game) play(m rules.Move, mover api.PlayerInfo) {
+	color := api.Color(g.pos.Turn)
+	g.pos = g.pos.Apply(m)
+	g.moves = append(g.moves, m.String())
+	g.lastMove = &api.MoveInfo{Move: m.String(), PlayerColor: color}
+	if mover.Type == api.Computer {
+		g.lastMove.Depth = 1
+	}
+	// Moving instead of answering declines the opponent's draw offer
+	if g.drawOffer != color {
+		g.drawOffer = api.NoColor
+	}
+	g.settle()
+	g.notify()
+}
+
+// end finishes the game off the board
+func (g *game) end(state api.GameState, termination api.Termination) {
+	g.state = state
+	g.termination = termination
+	g.drawOffer = api.NoColor
+	g.notify()
+}
+
+// history replays the game from its start position
+func (g *game) history() (*rules.History, error) {
+	return rules.Replay(g.startFEN, g.moves)
+}
+
+// material returns White's material minus Black's, in pawns
+func material(pos *rules.Position) int {
+	values := map[byte]int{'P': 1, 'N': 3, 'B': 3, 'R': 5, 'Q': 9}
+	balance := 0
+	for _, pc := range pos.Board {
+		if pc >= 'a' {
+			balance -= values[pc-'a'+'A']
+		} else {
+			balance += values[pc]
+		}
+	}
+	return balance
+}
+
+// settle reports a finished game the way the server does, naming the winner
+// of a checkmate and ending the game by the rules that apply without a
+// claim; threefold repetition and the fifty-move rule wait for one
+func (g *game) settle() {
+	g.termination = ""
+	switch g.pos.Status() {
+	case rules.Checkmate:
+		g.state = api.StateWhiteWins
+		if g.pos.Turn == rules.White {
+			g.state = api.StateBlackWins
+		}
+	case rules.Stalemate:
+		g.state = api.StateStalemate
+	case rules.InsufficientMaterial:
+		g.state, g.termination = api.StateDraw, api.TermInsufficient
+	case rules.SeventyFiveMoves:
+		g.state, g.termination = api.StateDraw, api.TermSeventyFive
+	default:
+		g.state = api.StateOngoing
+		if g.repetitions() >= rules.AutoDrawRepetitions {
+			g.state, g.termination = api.StateDraw, api.TermFivefold
+		}
+	}
+}
+
+// repetitions counts occurrences of the current position in the game
+func (g *game) repetitions() int {
+	h, err := g.history()
+	if err != nil {
+		return 0
+	}
+	return h.Repetitions()
+}
+
+// rewind replays the game from the start position up to n moves
+func (g *game) rewind(n int) error {
+	pos, err := rules.ParseFEN(g.startFEN)
+	if err != nil {
+		return err
+	}
+	for _, uci := range g.moves[:n] {
+		m, err := pos.ParseMove(uci)
+		if err != nil {
+			return err
+		}
+		pos = pos.Apply(m)
+	}
+	g.pos = pos
+	g.moves = g.moves[:n]
+	g.lastMove = nil
+	g.drawOffer = api.NoColor
+	g.settle()
+	g.notify()
+	return nil
+}
+
+func (g *game) notify() {
+	g.updated = time.Now()
+	close(g.changed)
+	g.changed = make(chan struct{})
+}
+
+func (g *game) summary() api.GameSummary {
+	return api.GameSummary{
+		GameID:    g.id,
+		Turn:      api.Color(g.pos.Turn),
+		State:     g.state,
+		MoveCount: len(g.moves),
+		Players:   api.PlayersResponse{White: g.white, Black: g.black},
+		CreatedAt: g.created,
+		UpdatedAt: g.updated,
+	}
+}
+
+func (g *game) response() *api.GameResponse {
+	resp := &api.GameResponse{
+		GameID:  g.id,
+		FEN:     g.pos.FEN(),
+		Turn:    api.Color(g.pos.Turn),
+		State:   g.state,
+		Moves:   append([]string{}, g.moves...),
+		Players: api.PlayersResponse{White: g.white, Black: g.black},
+
+		DrawOffer:   g.drawOffer,
+		Termination: g.termination,
+	}
+	if g.lastMove != nil {
+		lm := *g.lastMove
+		resp.LastMove = &lm
+	}
+	return resp
+}
@@ -0,0 +1,693 @@
+// FILE: lixenwraith/chess/internal/client/clienttest/server.go
+// Package clienttest provides an in-process fake of the chess server API for
+// tests and offline development.
+package clienttest
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/rules"
+)
+
+const DefaultPollTimeout = 25 * time.Second
+
+// Failure describes an injected error response
+type Failure struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+// Fake is an in-memory implementation of the chess server API
+type Fake struct {
+	mux *http.ServeMux
+
+	mu            sync.Mutex
+	games         map[string]*game
+	users         map[string]*user // by user ID
+	tokens        map[string]*user
+	failures      map[string][]Failure
+	latency       time.Duration
+	computerDelay time.Duration
+	pollTimeout   time.Duration
+	computerMoves []string
+}
+
+type user struct {
+	id        string
+	username  string
+	email     string
+	password  string
+	createdAt time.Time
+	lastLogin *time.Time
+}
+
+// NewFake creates a fake server handler with no games or users
+func NewFake() *Fake {
+	f := &Fake{
+		mux:         http.NewServeMux(),
+		games:       make(map[string]*game),
+		users:       make(map[string]*user),
+		tokens:      make(map[string]*user),
+		failures:    make(map[string][]Failure),
+		pollTimeout: DefaultPollTimeout,
+	}
+
+	f.handle("GET /health", f.health)
+	f.handle("POST /api/v1/games", f.createGame)
+	f.handle("GET /api/v1/games", f.listGames)
+	f.handle("GET /api/v1/games/{id}", f.getGame)
+	f.handle("DELETE /api/v1/games/{id}", f.deleteGame)
+	f.handle("POST /api/v1/games/{id}/moves", f.makeMove)
+	f.handle("POST /api/v1/games/{id}/undo", f.undo)
+	f.handle("POST /api/v1/games/{id}/resign", f.resign)
+	f.handle("POST /api/v1/games/{id}/draw", f.draw)
+	f.handle("GET /api/v1/games/{id}/board", f.board)
+	f.handle("POST /api/v1/auth/register", f.register)
+	f.handle("POST /api/v1/auth/login", f.login)
+	f.handle("GET /api/v1/auth/me", f.me)
+
+	return f
+}
+
+// NewServer starts a fake on a local httptest server; callers must Close it
+func NewServer() (*Fake, *httptest.Server) {
+	f := NewFake()
+	return f, httptest.NewServer(f)
+}
+
+func (f *Fake) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mux.ServeHTTP(w, r)
+}
+
+// SetLatency delays every response by d
+func (f *Fake) SetLatency(d time.Duration) {
+	f.mu.Lock()
+	f.latency = d
+	f.mu.Unlock()
+}
+
+// SetComputerDelay controls how long computer moves stay pending; zero
+// applies them synchronously
+func (f *Fake) SetComputerDelay(d time.Duration) {
+	f.mu.Lock()
+	f.computerDelay = d
+	f.mu.Unlock()
+}
+
+// SetPollTimeout bounds how long long-poll requests wait for a change
+func (f *Fake) SetPollTimeout(d time.Duration) {
+	f.mu.Lock()
+	f.pollTimeout = d
+	f.mu.Unlock()
+}
+
+// QueueComputerMoves scripts the replies to subsequent computer move triggers
+func (f *Fake) QueueComputerMoves(moves ...string) {
+	f.mu.Lock()
+	f.computerMoves = append(f.computerMoves, moves...)
+	f.mu.Unlock()
+}
+
+// FailNext makes the next request matching route fail with the given failure.
+// Route uses the registration pattern, e.g. "POST /api/v1/games/{id}/moves"
+func (f *Fake) FailNext(route string, failure Failure) {
+	f.mu.Lock()
+	f.failures[route] = append(f.failures[route], failure)
+	f.mu.Unlock()
+}
+
+// SetState forces a game into the given state, e.g. api.StateDraw
+func (f *Fake) SetState(gameID string, state api.GameState) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	g, ok := f.games[gameID]
+	if !ok {
+		return false
+	}
+	g.state = state
+	g.notify()
+	return true
+}
+
+// Game returns a snapshot of a game as the API would render it
+func (f *Fake) Game(gameID string) (*api.GameResponse, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	g, ok := f.games[gameID]
+	if !ok {
+		return nil, false
+	}
+	return g.response(), true
+}
+
+// GameCount returns the number of live games
+func (f *Fake) GameCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.games)
+}
+
+func (f *Fake) handle(pattern string, h http.HandlerFunc) {
+	f.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		latency := f.latency
+		var failure *Failure
+		if queue := f.failures[pattern]; len(queue) > 0 {
+			failure = &queue[0]
+			f.failures[pattern] = queue[1:]
+		}
+		f.mu.Unlock()
+
+		if latency > 0 {
+			time.Sleep(latency)
+		}
+		if failure != nil {
+			writeError(w, failure.Status, failure.Code, failure.Message)
+			return
+		}
+		h(w, r)
+	})
+}
+
+func (f *Fake) health(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, &api.HealthResponse{
+		Status:  "healthy",
+		Time:    time.Now().Unix(),
+		Storage: "memory",
+	})
+}
+
+func (f *Fake) createGame(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateGameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "malformed request body")
+		return
+	}
+	for _, p := range []api.PlayerConfig{req.White, req.Black} {
+		if !p.Type.Valid() {
+			writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid player type")
+			return
+		}
+	}
+
+	fen := req.FEN
+	if fen == "" {
+		fen = rules.StartFEN
+	}
+	pos, err := rules.CheckFEN(fen)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_FEN", err.Error())
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// The authenticated user takes the first human seat
+	userID := ""
+	if u := f.authenticated(r); u != nil {
+		userID = u.id
+	}
+	g := &game{
+		id:       newID(),
+		startFEN: fen,
+		pos:      pos,
+		created:  time.Now(),
+		changed:  make(chan struct{}),
+	}
+	g.updated = g.created
+	g.settle()
+	g.white = newPlayer(req.White, &userID)
+	g.black = newPlayer(req.Black, &userID)
+	f.games[g.id] = g
+
+	writeJSON(w, http.StatusCreated, g.response())
+}
+
+func (f *Fake) listGames(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	q := api.ListGamesQuery{State: params.Get("state")}
+	switch params.Get("opponent") {
+	case "":
+	case "human":
+		q.Opponent = api.Human
+	case "computer":
+		q.Opponent = api.Computer
+	default:
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid opponent filter")
+		return
+	}
+	for _, p := range []struct {
+		name string
+		dst  *int
+	}{{"limit", &q.Limit}, {"offset", &q.Offset}} {
+		if v := params.Get(p.name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid "+p.name)
+				return
+			}
+			*p.dst = n
+		}
+	}
+	if err := q.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	userID := ""
+	if player := params.Get("player"); player != "" {
+		u := f.authenticated(r)
+		if u == nil {
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		}
+		q.Mine = true
+		userID = u.id
+	}
+
+	games := make([]api.GameSummary, 0, len(f.games))
+	for _, g := range f.games {
+		games = append(games, g.summary())
+	}
+	writeJSON(w, http.StatusOK, api.PageGames(games, &q, userID))
+}
+
+func (f *Fake) getGame(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	f.mu.Lock()
+	g, ok := f.games[id]
+	if !ok {
+		f.mu.Unlock()
+		writeError(w, http.StatusNotFound, "GAME_NOT_FOUND", "game not found")
+		return
+	}
+
+	if r.URL.Query().Get("wait") == "true" {
+		moveCount, _ := strconv.Atoi(r.URL.Query().Get("moveCount"))
+		timeout := time.After(f.pollTimeout)
+		for len(g.moves) <= moveCount && f.games[id] == g {
+			changed := g.changed
+			f.mu.Unlock()
+			select {
+			case <-changed:
+			case <-timeout:
+				f.mu.Lock()
+				writeJSON(w, http.StatusOK, g.response())
+				f.mu.Unlock()
+				return
+			case <-r.Context().Done():
+				return
+			}
+			f.mu.Lock()
+		}
+	}
+
+	resp := g.response()
+	f.mu.Unlock()
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (f *Fake) deleteGame(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	g, ok := f.games[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "GAME_NOT_FOUND", "game not found")
+		return
+	}
+	delete(f.games, id)
+	g.notify()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *Fake) makeMove(w http.ResponseWriter, r *http.Request) {
+	var req api.MoveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "malformed request body")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	g, ok := f.games[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "GAME_NOT_FOUND", "game not found")
+		return
+	}
+	switch g.state {
+	case api.StatePending:
+		writeError(w, http.StatusConflict, "GAME_PENDING", "computer move in progress")
+		return
+	case api.StateOngoing:
+	default:
+		writeError(w, http.StatusBadRequest, "GAME_OVER", "game is already over")
+		return
+	}
+
+	mover := g.playerToMove()
+	if req.Move == "cccc" {
+		if mover.Type != api.Computer {
+			writeError(w, http.StatusBadRequest, "NOT_COMPUTER_TURN", "side to move is not a computer")
+			return
+		}
+		move, err := f.nextComputerMove(g)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "ENGINE_ERROR", err.Error())
+			return
+		}
+		if f.computerDelay == 0 {
+			g.play(move, mover)
+			writeJSON(w, http.StatusOK, g.response())
+			return
+		}
+
+		g.state = api.StatePending
+		delay := f.computerDelay
+		go func() {
+			time.Sleep(delay)
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if f.games[g.id] == g && g.state == api.StatePending {
+				g.state = api.StateOngoing
+				g.play(move, mover)
+			}
+		}()
+		writeJSON(w, http.StatusOK, g.response())
+		return
+	}
+
+	if mover.Type != api.Human {
+		writeError(w, http.StatusBadRequest, "NOT_HUMAN_TURN", "side to move is a computer")
+		return
+	}
+	m, err := g.pos.ParseMove(req.Move)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_MOVE", err.Error())
+		return
+	}
+	g.play(m, mover)
+	writeJSON(w, http.StatusOK, g.response())
+}
+
+func (f *Fake) undo(w http.ResponseWriter, r *http.Request) {
+	var req api.UndoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "malformed request body")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	g, ok := f.games[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "GAME_NOT_FOUND", "game not found")
+		return
+	}
+	if g.state == api.StatePending {
+		writeError(w, http.StatusConflict, "GAME_PENDING", "computer move in progress")
+		return
+	}
+	if req.Count < 1 || req.Count > len(g.moves) {
+		writeError(w, http.StatusBadRequest, "INVALID_UNDO",
+			fmt.Sprintf("cannot undo %d of %d moves", req.Count, len(g.moves)))
+		return
+	}
+	if err := g.rewind(len(g.moves) - req.Count); err != nil {
+		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, g.response())
+}
+
+// ongoingGame looks up a game that can still be ended, writing the error
+// response when it cannot
+func (f *Fake) ongoingGame(w http.ResponseWriter, r *http.Request) (*game, bool) {
+	g, ok := f.games[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "GAME_NOT_FOUND", "game not found")
+		return nil, false
+	}
+	switch g.state {
+	case api.StateOngoing:
+		return g, true
+	case api.StatePending:
+		writeError(w, http.StatusConflict, "GAME_PENDING", "computer move in progress")
+	default:
+		writeError(w, http.StatusBadRequest, "GAME_OVER", "game is already over")
+	}
+	return nil, false
+}
+
+func (f *Fake) resign(w http.ResponseWriter, r *http.Request) {
+	var req api.ResignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !req.Color.Valid() {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "malformed request body")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	g, ok := f.ongoingGame(w, r)
+	if !ok {
+		return
+	}
+	if g.player(req.Color).Type != api.Human {
+		writeError(w, http.StatusBadRequest, "NOT_HUMAN", "only a human side can resign")
+		return
+	}
+	winner := api.StateWhiteWins
+	if req.Color == api.White {
+		winner = api.StateBlackWins
+	}
+	g.end(winner, api.TermResignation)
+	writeJSON(w, http.StatusOK, g.response())
+}
+
+func (f *Fake) draw(w http.ResponseWriter, r *http.Request) {
+	var req api.DrawRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !req.Color.Valid() {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "malformed request body")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	g, ok := f.ongoingGame(w, r)
+	if !ok {
+		return
+	}
+	if g.player(req.Color).Type != api.Human {
+		writeError(w, http.StatusBadRequest, "NOT_HUMAN", "only a human side can act on draws")
+		return
+	}
+
+	switch req.Action {
+	case api.DrawOffer:
+		// A computer opponent answers at once, taking the draw unless it is
+		// ahead in material
+		if opponent := req.Color.Other(); g.player(opponent).Type == api.Computer {
+			balance := material(g.pos)
+			if opponent == api.Black {
+				balance = -balance
+			}
+			if balance <= 0 {
+				g.end(api.StateDraw, api.TermAgreement)
+			}
+			break
+		}
+		g.drawOffer = req.Color
+		g.notify()
+
+	case api.DrawAccept, api.DrawDecline:
+		if g.drawOffer != req.Color.Other() {
+			writeError(w, http.StatusBadRequest, "NO_DRAW_OFFER", "opponent has not offered a draw")
+			return
+		}
+		if req.Action == api.DrawAccept {
+			g.end(api.StateDraw, api.TermAgreement)
+		} else {
+			g.drawOffer = api.NoColor
+			g.notify()
+		}
+
+	case api.DrawClaim:
+		valid := false
+		switch req.Reason {
+		case api.TermFiftyMoves:
+			valid = g.pos.Halfmove >= rules.ClaimPlies
+		case api.TermRepetition:
+			h, err := g.history()
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+				return
+			}
+			valid = h.Repetitions() >= 3
+		default:
+			writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "unknown claim reason")
+			return
+		}
+		if !valid {
+			writeError(w, http.StatusBadRequest, "INVALID_CLAIM", fmt.Sprintf("no draw by %s", req.Reason))
+			return
+		}
+		g.end(api.StateDraw, req.Reason)
+
+	default:
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "unknown draw action")
+		return
+	}
+	writeJSON(w, http.StatusOK, g.response())
+}
+
+func (f *Fake) board(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	g, ok := f.games[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "GAME_NOT_FOUND", "game not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, &api.BoardResponse{
+		FEN:   g.pos.FEN(),
+		Board: g.pos.ASCII(),
+	})
+}
+
+func (f *Fake) register(w http.ResponseWriter, r *http.Request) {
+	var req api.RegisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "malformed request body")
+		return
+	}
+	if req.Username == "" || req.Password == "" {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "username and password required")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, u := range f.users {
+		if strings.EqualFold(u.username, req.Username) {
+			writeError(w, http.StatusConflict, "USER_EXISTS", "username already taken")
+			return
+		}
+	}
+	u := &user{
+		id:        newID(),
+		username:  req.Username,
+		email:     req.Email,
+		password:  req.Password,
+		createdAt: time.Now().UTC(),
+	}
+	f.users[u.id] = u
+	writeJSON(w, http.StatusCreated, f.issueToken(u))
+}
+
+func (f *Fake) login(w http.ResponseWriter, r *http.Request) {
+	var req api.LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "malformed request body")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, u := range f.users {
+		matches := strings.EqualFold(u.username, req.Identifier) ||
+			(u.email != "" && strings.EqualFold(u.email, req.Identifier))
+		if matches && u.password == req.Password {
+			now := time.Now().UTC()
+			u.lastLogin = &now
+			writeJSON(w, http.StatusOK, f.issueToken(u))
+			return
+		}
+	}
+	writeError(w, http.StatusUnauthorized, "INVALID_CREDENTIALS", "invalid credentials")
+}
+
+func (f *Fake) me(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u := f.authenticated(r)
+	if u == nil {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		return
+	}
+	writeJSON(w, http.StatusOK, &api.UserResponse{
+		UserID:    u.id,
+		Username:  u.username,
+		Email:     u.email,
+		CreatedAt: u.createdAt,
+		LastLogin: u.lastLogin,
+	})
+}
+
+// issueToken must be called with f.mu held
+func (f *Fake) issueToken(u *user) *api.AuthResponse {
+	token := "fake-" + newID()
+	f.tokens[token] = u
+	return &api.AuthResponse{Token: token, UserID: u.id, Username: u.username}
+}
+
+// authenticated must be called with f.mu held
+func (f *Fake) authenticated(r *http.Request) *user {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return nil
+	}
+	return f.tokens[token]
+}
+
+// nextComputerMove returns the next scripted reply, or the first legal move.
+// Must be called with f.mu held
+func (f *Fake) nextComputerMove(g *game) (rules.Move, error) {
+	if len(f.computerMoves) > 0 {
+		uci := f.computerMoves[0]
+		f.computerMoves = f.computerMoves[1:]
+		m, err := g.pos.ParseMove(uci)
+		if err != nil {
+			return rules.Move{}, fmt.Errorf("scripted move %s: %w", uci, err)
+		}
+		return m, nil
+	}
+	moves := g.pos.LegalMoves()
+	if len(moves) == 0 {
+		return rules.Move{}, fmt.Errorf("no legal moves")
+	}
+	return moves[0], nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, &api.ErrorResponse{Error: message, Code: code})
+}
+
+func newID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,296 @@
+// FILE: lixenwraith/chess/internal/client/clock/clock.go
+// Package clock keeps chess clocks on the client, since the server has no
+// notion of game time.
+package clock
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"chess/internal/client/api"
+)
+
+// Mode is how the per-move time is credited
+type Mode int
+
+const (
+	// Fischer adds the increment after every move
+	Fischer Mode = iota
+	// Bronstein gives back the time used on a move, up to the increment
+	Bronstein
+)
+
+// TimeControl is base time plus a per-move increment or delay, with the
+// base time added again every Moves moves when Moves is set
+type TimeControl struct {
+	Base      time.Duration
+	Increment time.Duration
+	Mode      Mode
+	Moves     int // moves per period, 0 for a single period
+}
+
+// ParseTimeControl reads "[moves/]base[+inc|dinc]" with base in minutes,
+// or minutes:seconds, and the increment or Bronstein delay in seconds, e.g.
+// "5+3", "3d2", "40/90+30" or "0:30"
+func ParseTimeControl(s string) (TimeControl, error) {
+	var tc TimeControl
+	rest := strings.TrimSpace(s)
+
+	if moves, after, ok := strings.Cut(rest, "/"); ok {
+		n, err := strconv.Atoi(moves)
+		if err != nil || n < 1 {
+			return tc, fmt.Errorf("invalid moves per period %q", moves)
+		}
+		tc.Moves, rest = n, after
+	}
+
+	base, inc := rest, ""
+	if i := strings.IndexAny(rest, "+d"); i >= 0 {
+		base, inc = rest[:i], rest[i+1:]
+		if rest[i] == 'd' {
+			tc.Mode = Bronstein
+		}
+	}
+
+	minutes, seconds, hasSeconds := strings.Cut(base, ":")
+	m, err := strconv.ParseFloat(minutes, 64)
+	if err != nil || m < 0 {
+		return tc, fmt.Errorf("invalid base time %q", base)
+	}
+	tc.Base = time.Duration(m * float64(time.Minute))
+	if hasSeconds {
+		sec, err := strconv.Atoi(seconds)
+		if err != nil || sec < 0 || sec > 59 || strings.Contains(minutes, ".") {
+			return tc, fmt.Errorf("invalid base time %q", base)
+		}
+		tc.Base += time.Duration(sec) * time.Second
+	}
+	if tc.Base <= 0 {
+		return tc, fmt.Errorf("base time must be positive")
+	}
+
+	if inc != "" {
+		sec, err := strconv.ParseFloat(inc, 64)
+		if err != nil || sec < 0 {
+			return tc, fmt.Errorf("invalid increment %q", inc)
+		}
+		tc.Increment = time.Duration(sec * float64(time.Second))
+	}
+	return tc, nil
+}
+
+// String renders the time control in the form ParseTimeControl reads
+func (tc TimeControl) String() string {
+	var b strings.Builder
+	if tc.Moves > 0 {
+		fmt.Fprintf(&b, "%d/", tc.Moves)
+	}
+	if tc.Base%time.Minute == 0 {
+		fmt.Fprintf(&b, "%d", int(tc.Base/time.Minute))
+	} else {
+		fmt.Fprintf(&b, "%d:%02d", int(tc.Base/time.Minute), int(tc.Base%time.Minute/time.Second))
+	}
+	if tc.Increment > 0 {
+		sep := "+"
+		if tc.Mode == Bronstein {
+			sep = "d"
+		}
+		fmt.Fprintf(&b, "%s%s", sep, strconv.FormatFloat(tc.Increment.Seconds(), 'f', -1, 64))
+	}
+	return b.String()
+}
+
+// PGN renders the time control for a PGN TimeControl tag, in seconds
+func (tc TimeControl) PGN() string {
+	s := strconv.Itoa(int(tc.Base.Seconds()))
+	if tc.Moves > 0 {
+		s = fmt.Sprintf("%d/%s", tc.Moves, s)
+	}
+	if tc.Increment > 0 {
+		s += "+" + strconv.FormatFloat(tc.Increment.Seconds(), 'f', -1, 64)
+	}
+	return s
+}
+
+// state is what the clock shows between two moves
+type state struct {
+	remaining [2]time.Duration
+	moves     [2]int
+}
+
+// Clock is a running chess clock, safe for concurrent use
+type Clock struct {
+	Control TimeControl
+
+	mu      sync.Mutex
+	cur     state
+	history []state         // state before each ply pressed, for undo
+	times   []time.Duration // mover's time left after each ply
+	turn    api.Color       // side whose time runs
+	since   time.Time       // when turn's time started running
+	stopped bool
+	flagged api.Color // side that ran out of time, NoColor if none
+}
+
+// New starts a clock with turn's time running
+func New(tc TimeControl, turn api.Color, now time.Time) *Clock {
+	return &Clock{
+		Control: tc,
+		cur:     state{remaining: [2]time.Duration{tc.Base, tc.Base}},
+		turn:    turn,
+		since:   now,
+	}
+}
+
+func side(c api.Color) int {
+	if c == api.Black {
+		return 1
+	}
+	return 0
+}
+
+// Remaining returns the time left for color as of now
+func (c *Clock) Remaining(color api.Color, now time.Time) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.remainingLocked(color, now)
+}
+
+func (c *Clock) remainingLocked(color api.Color, now time.Time) time.Duration {
+	left := c.cur.remaining[side(color)]
+	if color == c.turn && !c.stopped {
+		left -= now.Sub(c.since)
+	}
+	return max(0, left)
+}
+
+// Turn returns the side whose time runs, NoColor once stopped
+func (c *Clock) Turn() api.Color {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopped {
+		return api.NoColor
+	}
+	return c.turn
+}
+
+// Press ends the move of the side whose time runs and starts the other
+// side's time. It returns false, stopping the clock, if the mover's time
+// had already run out
+func (c *Clock) Press(now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopped {
+		return c.flagged == api.NoColor
+	}
+
+	i := side(c.turn)
+	used := now.Sub(c.since)
+	if used >= c.cur.remaining[i] {
+		c.flagLocked()
+		return false
+	}
+
+	c.history = append(c.history, c.cur)
+	c.cur.remaining[i] -= used
+	switch c.Control.Mode {
+	case Fischer:
+		c.cur.remaining[i] += c.Control.Increment
+	case Bronstein:
+		c.cur.remaining[i] += min(used, c.Control.Increment)
+	}
+	c.cur.moves[i]++
+	if c.Control.Moves > 0 && c.cur.moves[i]%c.Control.Moves == 0 {
+		c.cur.remaining[i] += c.Control.Base
+	}
+	c.times = append(c.times, c.cur.remaining[i])
+
+	c.turn = c.turn.Other()
+	c.since = now
+	return true
+}
+
+// Undo takes back plies, restoring the times from before them; the side
+// to move then starts with its time running
+func (c *Clock) Undo(plies int, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	plies = min(plies, len(c.history))
+	if plies <= 0 {
+		return
+	}
+	n := len(c.history) - plies
+	c.cur = c.history[n]
+	c.history = c.history[:n]
+	c.times = c.times[:n]
+	if plies%2 == 1 {
+		c.turn = c.turn.Other()
+	}
+	c.since = now
+	c.stopped = false
+	c.flagged = api.NoColor
+}
+
+// Stop freezes both times, when the game ends on the board
+func (c *Clock) Stop(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.stopped {
+		c.cur.remaining[side(c.turn)] = c.remainingLocked(c.turn, now)
+		c.stopped = true
+	}
+}
+
+// Flagged returns the side that ran out of time as of now, NoColor if none
+func (c *Clock) Flagged(now time.Time) api.Color {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.stopped && c.remainingLocked(c.turn, now) == 0 {
+		c.flagLocked()
+	}
+	return c.flagged
+}
+
+func (c *Clock) flagLocked() {
+	c.cur.remaining[side(c.turn)] = 0
+	c.flagged = c.turn
+	c.stopped = true
+}
+
+// Plies returns the number of plies pressed since the clock started
+func (c *Clock) Plies() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.history)
+}
+
+// MoveTimes returns the mover's time left after each ply, as recorded in
+// PGN %clk comments
+func (c *Clock) MoveTimes() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]time.Duration(nil), c.times...)
+}
+
+// Format renders a clock time as h:mm:ss or m:ss, with tenths under ten
+// seconds
+func Format(d time.Duration) string {
+	d = max(0, d)
+	if d < 10*time.Second {
+		return fmt.Sprintf("0:%04.1f", float64(d.Truncate(100*time.Millisecond))/float64(time.Second))
+	}
+	h, m, s := int(d/time.Hour), int(d%time.Hour/time.Minute), int(d%time.Minute/time.Second)
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+// FormatPGN renders a clock time for a %clk comment, h:mm:ss
+func FormatPGN(d time.Duration) string {
+	d = max(0, d)
+	return fmt.Sprintf("%d:%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute), int(d%time.Minute/time.Second))
+}
\ No newline at end of file
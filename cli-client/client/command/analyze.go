@@ -0,0 +1,169 @@
+// FILE: lixenwraith/chess/internal/client/command/analyze.go
+package command
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/display"
+	"chess/internal/client/engine"
+	"chess/internal/client/rules"
+	"chess/internal/client/session"
+)
+
+const analyzeUsage = "analyze [-times 250,500,1000,2000] [-level N] [fen]"
+
+func (r *Registry) registerAnalysisCommands() {
+	r.Register(&Command{
+		Name:        "analyze",
+		ShortName:   "a",
+		Description: "Show the engine's evaluation of a position",
+		Usage:       analyzeUsage,
+		Handler:     analyzeHandler,
+	})
+
+	r.Register(&Command{
+		Name:        "review",
+		ShortName:   "v",
+		Description: "Evaluate every move of the game and flag errors",
+		Usage:       reviewUsage,
+		Handler:     reviewHandler,
+	})
+
+	r.Register(&Command{
+		Name:        "hint",
+		ShortName:   "H",
+		Description: "Suggest a move for the human side to move",
+		Usage:       hintUsage,
+		Handler:     hintHandler,
+	})
+
+	r.Register(&Command{
+		Name:        "opening",
+		ShortName:   "O",
+		Description: "Name the opening and list book moves",
+		Usage:       "opening",
+		Handler:     openingHandler,
+	})
+}
+
+func analyzeHandler(s *session.Session, args []string) error {
+	var timesFlag string
+	var level int
+	fs := flag.NewFlagSet("analyze", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&timesFlag, "times", "250,500,1000,2000", "comma-separated search times, in ms or as durations")
+	fs.IntVar(&level, "level", engine.MaxLevel, "engine level 0-20")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%v\nusage: %s", err, analyzeUsage)
+	}
+	times, err := parseSearchTimes(timesFlag)
+	if err != nil {
+		return err
+	}
+	if level < 0 || level > engine.MaxLevel {
+		return fmt.Errorf("level must be 0-%d", engine.MaxLevel)
+	}
+
+	fen, err := analysisFEN(s, fs.Args())
+	if err != nil {
+		return err
+	}
+	pos, err := rules.CheckFEN(fen)
+	if err != nil {
+		return fenError(err)
+	}
+	if status := pos.Status(); status != rules.Ongoing {
+		return fmt.Errorf("nothing to analyze, position is over by %s", status)
+	}
+
+	display.Println(display.Cyan, "Analyzing %s (level %d)", fen, level)
+	display.Println(display.Cyan, "Scores are from White's point of view\n")
+	fmt.Printf("  %-8s  %5s  %7s  %s\n", "Time", "Depth", "Score", "Best move")
+
+	c := s.GetBackend()
+	for _, t := range times {
+		info, err := api.SearchPosition(c, fen, level, t)
+		if err != nil {
+			return fmt.Errorf("search for %s: %w", t, err)
+		}
+		score := info.Score
+		if pos.Turn == rules.Black {
+			score = -score
+		}
+		fmt.Printf("  %-8s  %5s  %s  %s\n", t, formatDepth(info.Depth),
+			display.C(scoreColor(score), fmt.Sprintf("%7s", formatScore(score))), info.Move)
+	}
+	return nil
+}
+
+// analysisFEN picks the position to analyze: the FEN given as arguments,
+// else the current game's position, else the start position
+func analysisFEN(s *session.Session, args []string) (string, error) {
+	if len(args) > 0 {
+		return strings.Join(args, " "), nil
+	}
+	if gameID := s.GetCurrentGame(); gameID != "" {
+		game, err := s.GetBackend().GetGame(gameID)
+		if err != nil {
+			return "", err
+		}
+		return game.FEN, nil
+	}
+	return rules.StartFEN, nil
+}
+
+// parseSearchTimes reads a list of search times given in milliseconds or as
+// Go durations, each within what the server accepts
+func parseSearchTimes(list string) ([]time.Duration, error) {
+	var times []time.Duration
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		t, err := time.ParseDuration(item)
+		if err != nil {
+			ms, err := strconv.Atoi(item)
+			if err != nil {
+				return nil, fmt.Errorf("invalid search time %q", item)
+			}
+			t = time.Duration(ms) * time.Millisecond
+		}
+		if t < api.MinSearchTime || t > api.MaxSearchTime {
+			return nil, fmt.Errorf("search time %s outside %s-%s", t, api.MinSearchTime, api.MaxSearchTime)
+		}
+		times = append(times, t)
+	}
+	return times, nil
+}
+
+// formatScore renders centipawns as pawns, and mate scores as moves to mate
+func formatScore(cp int) string {
+	switch {
+	case cp >= engine.MateScore-1000:
+		return fmt.Sprintf("#%d", (engine.MateScore-cp+1)/2)
+	case cp <= -engine.MateScore+1000:
+		return fmt.Sprintf("-#%d", (engine.MateScore+cp)/2)
+	}
+	return fmt.Sprintf("%+.2f", float64(cp)/100)
+}
+
+func formatDepth(depth int) string {
+	if depth == 0 {
+		return "-"
+	}
+	return strconv.Itoa(depth)
+}
+
+func scoreColor(cp int) string {
+	switch {
+	case cp > 50:
+		return display.Green
+	case cp < -50:
+		return display.Red
+	}
+	return display.Reset
+}
\ No newline at end of file
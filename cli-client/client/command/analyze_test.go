@@ -0,0 +1,70 @@
+// FILE: lixenwraith/chess/internal/client/command/analyze_test.go
+package command
+
+import (
+	"testing"
+
+	"chess/internal/client/clienttest"
+)
+
+func TestAnalyzeCommand(t *testing.T) {
+	// Searches play in throwaway games that must not be left behind
+	cleanedUp := func(want int) func(e *testEnv) {
+		return func(e *testEnv) {
+			if e.fake.GameCount() != want {
+				e.t.Fatalf("%d games on the server, want %d", e.fake.GameCount(), want)
+			}
+		}
+	}
+
+	runCases(t, []commandCase{
+		{
+			name:  "start position",
+			line:  "analyze -times 100,200 -level 3",
+			check: cleanedUp(0),
+		},
+		{
+			name:  "current game",
+			setup: func(e *testEnv) { e.newGame(); e.mustRun("move e2e4") },
+			line:  "a -times 100",
+			check: cleanedUp(1),
+		},
+		{
+			name: "given position",
+			line: "analyze -times 100 6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1",
+		},
+		{
+			name:  "offline engine",
+			setup: func(e *testEnv) { e.mustRun("offline on") },
+			line:  "analyze -times 100 -level 1",
+		},
+		{
+			name:    "position already over",
+			line:    "analyze -times 100 R5k1/5ppp/8/8/8/8/8/6K1 b - - 1 1",
+			wantErr: "nothing to analyze",
+		},
+		{
+			name:    "illegal position",
+			line:    "analyze -times 100 8/8/8/8/8/8/8/K3K3 w - - 0 1",
+			wantErr: "FEN rejected",
+		},
+		{
+			name:    "search time too short",
+			line:    "analyze -times 5",
+			wantErr: "outside",
+		},
+		{
+			name:    "level out of range",
+			line:    "analyze -level 99",
+			wantErr: "level must be",
+		},
+		{
+			name: "server error",
+			setup: func(e *testEnv) {
+				e.fake.FailNext("POST /api/v1/games", clienttest.Failure{Status: 500, Code: "INTERNAL", Message: "boom"})
+			},
+			line:    "analyze -times 100",
+			wantErr: "status 500",
+		},
+	})
+}
\ No newline at end of file
@@ -7,7 +7,6 @@ import (
 	"os"
 	"strings"
 
-	"chess/internal/client/api"
 	"chess/internal/client/display"
 	"chess/internal/client/session"
 )
@@ -56,7 +55,7 @@ func (r *Registry) registerAuthCommands() {
 
 func registerHandler(s *session.Session, args []string) error {
 	scanner := bufio.NewScanner(os.Stdin)
-	c := s.GetClient().(*api.Client)
+	c := s.GetBackend()
 
 	display.Print(display.Yellow, "Username: ")
 	scanner.Scan()
@@ -76,9 +75,7 @@ func registerHandler(s *session.Session, args []string) error {
 		return err
 	}
 
-	s.SetAuthToken(resp.Token)
-	s.SetCurrentUser(resp.UserID)
-	s.SetUsername(resp.Username)
+	s.Authenticate(resp.Token, resp.UserID, resp.Username)
 	c.SetToken(resp.Token)
 
 	display.Println(display.Green, "Registered successfully")
@@ -90,7 +87,7 @@ func registerHandler(s *session.Session, args []string) error {
 
 func loginHandler(s *session.Session, args []string) error {
 	scanner := bufio.NewScanner(os.Stdin)
-	c := s.GetClient().(*api.Client)
+	c := s.GetBackend()
 
 	display.Print(display.Yellow, "Username or Email: ")
 	scanner.Scan()
@@ -106,9 +103,7 @@ func loginHandler(s *session.Session, args []string) error {
 		return err
 	}
 
-	s.SetAuthToken(resp.Token)
-	s.SetCurrentUser(resp.UserID)
-	s.SetUsername(resp.Username)
+	s.Authenticate(resp.Token, resp.UserID, resp.Username)
 	c.SetToken(resp.Token)
 
 	display.Println(display.Green, "Logged in successfully")
@@ -119,10 +114,8 @@ func loginHandler(s *session.Session, args []string) error {
 }
 
 func logoutHandler(s *session.Session, args []string) error {
-	s.SetAuthToken("")
-	s.SetCurrentUser("")
-	s.SetUsername("")
-	c := s.GetClient().(*api.Client)
+	s.Authenticate("", "", "")
+	c := s.GetBackend()
 	c.SetToken("")
 
 	display.Println(display.Green, "Logged out")
@@ -135,7 +128,7 @@ func whoamiHandler(s *session.Session, args []string) error {
 		return nil
 	}
 
-	c := s.GetClient().(*api.Client)
+	c := s.GetBackend()
 	user, err := c.GetCurrentUser()
 	if err != nil {
 		return err
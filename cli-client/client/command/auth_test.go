@@ -0,0 +1,153 @@
+// FILE: lixenwraith/chess/internal/client/command/auth_test.go
+package command
+
+import (
+	"testing"
+)
+
+// stubPassword answers password prompts with password for one test
+func stubPassword(t *testing.T, password string) {
+	t.Helper()
+	old := readPassword
+	readPassword = func(string) (string, error) { return password, nil }
+	t.Cleanup(func() { readPassword = old })
+}
+
+// registerUser creates an account on the fake without touching the session
+func registerUser(e *testEnv, username, password string) {
+	e.t.Helper()
+	if _, err := e.client.Register(username, password, ""); err != nil {
+		e.t.Fatalf("register %s: %v", username, err)
+	}
+}
+
+func TestRegisterCommand(t *testing.T) {
+	stubPassword(t, "secret")
+	runCases(t, []commandCase{
+		{
+			name:  "new user",
+			line:  "register",
+			input: []string{"alice", "alice@example.com"},
+			check: func(e *testEnv) {
+				if e.session.GetUsername() != "alice" || e.session.GetAuthToken() == "" {
+					e.t.Fatalf("session not authenticated: %q %q", e.session.GetUsername(), e.session.GetAuthToken())
+				}
+			},
+		},
+		{
+			name:    "username taken",
+			setup:   func(e *testEnv) { registerUser(e, "alice", "other") },
+			line:    "r",
+			input:   []string{"alice", ""},
+			wantErr: "status 409",
+		},
+		{
+			name:    "missing username",
+			line:    "register",
+			input:   []string{"", ""},
+			wantErr: "status 400",
+		},
+	})
+}
+
+func TestLoginCommand(t *testing.T) {
+	stubPassword(t, "secret")
+	runCases(t, []commandCase{
+		{
+			name:  "valid credentials",
+			setup: func(e *testEnv) { registerUser(e, "bob", "secret") },
+			line:  "login",
+			input: []string{"bob"},
+			check: func(e *testEnv) {
+				if e.session.GetUsername() != "bob" || e.session.GetCurrentUser() == "" {
+					e.t.Fatalf("session not authenticated as bob: %q", e.session.GetUsername())
+				}
+			},
+		},
+		{
+			name:    "wrong password",
+			setup:   func(e *testEnv) { registerUser(e, "bob", "hunter2") },
+			line:    "l",
+			input:   []string{"bob"},
+			wantErr: "status 401",
+			check: func(e *testEnv) {
+				if e.session.GetAuthToken() != "" {
+					e.t.Fatal("failed login left a token")
+				}
+			},
+		},
+	})
+}
+
+func TestLogoutCommand(t *testing.T) {
+	stubPassword(t, "secret")
+	runCases(t, []commandCase{
+		{
+			name: "logged in",
+			setup: func(e *testEnv) {
+				registerUser(e, "carol", "secret")
+				e.mustRun("login", "carol")
+			},
+			line: "logout",
+			check: func(e *testEnv) {
+				if e.session.GetAuthToken() != "" || e.session.GetUsername() != "" {
+					e.t.Fatal("logout kept credentials")
+				}
+				// The client no longer authenticates either
+				if _, err := e.client.GetCurrentUser(); err == nil {
+					e.t.Fatal("client still authenticated after logout")
+				}
+			},
+		},
+		{
+			name: "not logged in",
+			line: "o",
+		},
+	})
+}
+
+func TestWhoamiCommand(t *testing.T) {
+	stubPassword(t, "secret")
+	runCases(t, []commandCase{
+		{
+			name: "logged in",
+			setup: func(e *testEnv) {
+				registerUser(e, "dave", "secret")
+				e.mustRun("login", "dave")
+			},
+			line: "whoami",
+		},
+		{
+			name: "not logged in",
+			line: "i",
+		},
+		{
+			name: "stale token",
+			setup: func(e *testEnv) {
+				e.session.Authenticate("stale", "user-1", "dave")
+				e.client.SetToken("stale")
+			},
+			line:    "whoami",
+			wantErr: "status 401",
+		},
+	})
+}
+
+func TestUserCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name: "set id",
+			line: "user user-42",
+			check: func(e *testEnv) {
+				if e.session.GetCurrentUser() != "user-42" {
+					e.t.Fatalf("current user = %q", e.session.GetCurrentUser())
+				}
+			},
+		},
+		{
+			name:    "missing id",
+			line:    "e",
+			wantErr: "usage",
+		},
+	})
+}
\ No newline at end of file
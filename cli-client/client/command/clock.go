@@ -0,0 +1,160 @@
+// FILE: lixenwraith/chess/internal/client/command/clock.go
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/clock"
+	"chess/internal/client/display"
+	"chess/internal/client/session"
+)
+
+const clockUsage = "clock | clock start <[moves/]base[+inc|dinc]> | clock stop"
+
+func (r *Registry) registerClockCommands() {
+	r.Register(&Command{
+		Name:        "clock",
+		ShortName:   "T",
+		Description: "Run a chess clock for the current game",
+		Usage:       clockUsage,
+		Handler:     clockHandler,
+	})
+}
+
+func clockHandler(s *session.Session, args []string) error {
+	gameID := s.GetCurrentGame()
+	if gameID == "" {
+		return fmt.Errorf("no current game, use 'new' or 'join <gameId>'")
+	}
+
+	if len(args) == 0 {
+		if s.GetClock(gameID) == nil {
+			display.Println(display.Yellow, "No clock, use 'clock start <time control>'")
+			return nil
+		}
+		printClock(s, gameID)
+		return nil
+	}
+
+	switch args[0] {
+	case "start":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: %s", clockUsage)
+		}
+		tc, err := clock.ParseTimeControl(args[1])
+		if err != nil {
+			return err
+		}
+		game, err := s.GetBackend().GetGame(gameID)
+		if err != nil {
+			return err
+		}
+		if game.State.IsOver() {
+			return fmt.Errorf("game is over")
+		}
+		startClock(s, game, tc)
+		return nil
+	case "stop":
+		if s.GetClock(gameID) == nil {
+			return fmt.Errorf("no clock running")
+		}
+		s.SetClock(nil)
+		display.Println(display.Green, "Clock removed")
+		return nil
+	}
+	return fmt.Errorf("usage: %s", clockUsage)
+}
+
+// promptTimeControl asks for an optional time control for a new game
+func promptTimeControl(scanner *bufio.Scanner) (*clock.TimeControl, error) {
+	display.Print(display.Yellow, "Time control (e.g. 5+3, 3d2, 40/90+30) [none]: ")
+	scanner.Scan()
+	input := strings.TrimSpace(scanner.Text())
+	if input == "" || input == "none" {
+		return nil, nil
+	}
+	tc, err := clock.ParseTimeControl(input)
+	if err != nil {
+		return nil, err
+	}
+	return &tc, nil
+}
+
+// startClock attaches a clock to the game with the side to move running
+func startClock(s *session.Session, game *api.GameResponse, tc clock.TimeControl) {
+	s.SetClock(&session.GameClock{
+		Clock:    clock.New(tc, game.Turn, time.Now()),
+		GameID:   game.GameID,
+		StartPly: len(game.Moves),
+	})
+	display.Println(display.Green, "Clock started: %s (%s running)", describeTimeControl(tc), game.Turn)
+}
+
+func describeTimeControl(tc clock.TimeControl) string {
+	s := tc.String()
+	switch {
+	case tc.Increment == 0:
+		return s + " sudden death"
+	case tc.Mode == clock.Bronstein:
+		return s + " Bronstein delay"
+	}
+	return s + " Fischer"
+}
+
+// printClock shows both sides' time, the running side highlighted
+func printClock(s *session.Session, gameID string) {
+	gc := s.GetClock(gameID)
+	if gc == nil {
+		return
+	}
+	now := time.Now()
+	flagged := gc.Flagged(now)
+	running := gc.Turn()
+
+	var b display.Builder
+	b.Add("", "Clock: ")
+	for i, color := range []api.Color{api.White, api.Black} {
+		if i > 0 {
+			b.Add("", " | ")
+		}
+		text := fmt.Sprintf("%s %s", color, clock.Format(gc.Remaining(color, now)))
+		switch color {
+		case flagged:
+			b.Add(display.Red, text+" (flag)")
+		case running:
+			b.Add(display.Green, text+" *")
+		default:
+			b.Add("", text)
+		}
+	}
+	b.Add("", fmt.Sprintf("  (%s)", describeTimeControl(gc.Control)))
+	fmt.Println(b.String())
+}
+
+// checkFlag reports a flag fall once, ending the game on the backend as a
+// loss for the flagged side, and refuses further play in a game lost on
+// time
+func checkFlag(s *session.Session, gameID string) error {
+	gc := s.GetClock(gameID)
+	if gc == nil {
+		return nil
+	}
+	flagged := gc.Flagged(time.Now())
+	if !flagged.Valid() {
+		return nil
+	}
+	if s.ClaimFlag(gameID) {
+		display.Println(display.Green, "\n%s flag fell, %s wins on time", flagged, flagged.Other())
+		// Servers have no time forfeit, so the flagged side resigns
+		if game, err := s.GetBackend().Resign(gameID, flagged); err != nil {
+			display.Println(display.Yellow, "Could not end the game on the server: %v", err)
+		} else {
+			s.UpdateGame(game)
+		}
+	}
+	return fmt.Errorf("game over, %s lost on time", flagged)
+}
\ No newline at end of file
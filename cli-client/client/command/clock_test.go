@@ -0,0 +1,111 @@
+// FILE: lixenwraith/chess/internal/client/command/clock_test.go
+package command
+
+import (
+	"testing"
+	"time"
+
+	"chess/internal/client/api"
+)
+
+func TestClockCommand(t *testing.T) {
+	running := func(color api.Color) func(e *testEnv) {
+		return func(e *testEnv) {
+			gc := e.session.GetClock(e.gameID)
+			if gc == nil || gc.Turn() != color {
+				e.t.Fatalf("clock %+v, want %s running", gc, color)
+			}
+		}
+	}
+
+	runCases(t, []commandCase{
+		{
+			name:  "start",
+			setup: func(e *testEnv) { e.newGame() },
+			line:  "clock start 5+3",
+			check: running(api.White),
+		},
+		{
+			name:  "moves press the clock",
+			setup: func(e *testEnv) { e.newGame(); e.mustRun("clock start 3d2") },
+			line:  "move e2e4",
+			check: running(api.Black),
+		},
+		{
+			name:  "time control from new game",
+			line:  "new",
+			input: []string{"h", "h", "", "40/90+30"},
+			check: func(e *testEnv) {
+				e.gameID = e.session.GetCurrentGame()
+				running(api.White)(e)
+			},
+		},
+		{
+			name:  "status",
+			setup: func(e *testEnv) { e.newGame(); e.mustRun("clock start 0:30") },
+			line:  "T",
+		},
+		{
+			name:  "stop",
+			setup: func(e *testEnv) { e.newGame(); e.mustRun("clock start 5") },
+			line:  "clock stop",
+			check: func(e *testEnv) {
+				if e.session.GetClock(e.gameID) != nil {
+					e.t.Fatal("clock still attached")
+				}
+			},
+		},
+		{
+			name: "flag fall stops play",
+			setup: func(e *testEnv) {
+				e.newGame()
+				e.mustRun("clock start 0.001")
+				time.Sleep(100 * time.Millisecond)
+			},
+			line:    "move e2e4",
+			wantErr: "lost on time",
+			check: func(e *testEnv) {
+				game, _ := e.fake.Game(e.gameID)
+				if game.State != api.StateBlackWins || len(game.Moves) != 0 {
+					e.t.Fatalf("server game %s after %v, want White's loss with no moves", game.State, game.Moves)
+				}
+			},
+		},
+		{
+			name: "computer flag fall",
+			setup: func(e *testEnv) {
+				e.newComputerGame()
+				e.fake.SetComputerDelay(time.Second)
+				e.mustRun("clock start 0.002")
+				e.mustRun("move e2e4")
+				time.Sleep(200 * time.Millisecond)
+			},
+			line: "show",
+			check: func(e *testEnv) {
+				if game, _ := e.fake.Game(e.gameID); game.State.IsOver() {
+					e.t.Fatalf("computer side resigned: %s", game.State)
+				}
+				if checkFlag(e.session, e.gameID) == nil {
+					e.t.Fatal("play allowed after the computer's flag fell")
+				}
+			},
+		},
+		{
+			name:    "invalid time control",
+			setup:   func(e *testEnv) { e.newGame() },
+			line:    "clock start 5+x",
+			wantErr: "invalid increment",
+		},
+		{
+			name:    "stop without clock",
+			setup:   func(e *testEnv) { e.newGame() },
+			line:    "clock stop",
+			wantErr: "no clock running",
+		},
+		{
+			name:    "no current game",
+			line:    "clock",
+			wantErr: "no current game",
+		},
+	})
+}
\ No newline at end of file
@@ -0,0 +1,151 @@
+// FILE: lixenwraith/chess/internal/client/command/command_test.go
+package command
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/clienttest"
+	"chess/internal/client/session"
+)
+
+// testEnv is a session and registry wired to a fresh fake server
+type testEnv struct {
+	t        *testing.T
+	fake     *clienttest.Fake
+	client   *api.Client
+	session  *session.Session
+	registry *Registry
+	url      string // fake server address, substituted for {url} in lines
+	gameID   string // last game created, substituted for {game} in lines
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	fake, srv := clienttest.NewServer()
+	t.Cleanup(srv.Close)
+	fake.SetPollTimeout(200 * time.Millisecond)
+
+	client := api.New(srv.URL)
+	s := session.New(srv.URL, client)
+	t.Cleanup(func() {
+		if seat := s.GetEngineSeat(); seat != nil {
+			seat.Engine.Close()
+		}
+	})
+	return &testEnv{t: t, fake: fake, client: client, session: s, registry: NewRegistry(s), url: srv.URL}
+}
+
+// run executes a command line through its registered handler, answering
+// its prompts with input
+func (e *testEnv) run(line string, input ...string) error {
+	e.t.Helper()
+	parts := strings.Fields(strings.NewReplacer("{game}", e.gameID, "{url}", e.url).Replace(line))
+	cmd, ok := e.registry.commands[parts[0]]
+	if !ok {
+		e.t.Fatalf("command %q not registered", parts[0])
+	}
+	restore := setStdin(e.t, input)
+	defer restore()
+	return cmd.Handler(e.session, parts[1:])
+}
+
+// mustRun runs a setup command that has to succeed
+func (e *testEnv) mustRun(line string, input ...string) {
+	e.t.Helper()
+	if err := e.run(line, input...); err != nil {
+		e.t.Fatalf("%s: %v", line, err)
+	}
+}
+
+// newGame creates a human versus human game and makes it current
+func (e *testEnv) newGame() string {
+	e.t.Helper()
+	e.mustRun("new", "h", "h", "")
+	e.gameID = e.session.GetCurrentGame()
+	return e.gameID
+}
+
+// newComputerGame creates a game with the computer playing Black
+func (e *testEnv) newComputerGame() string {
+	e.t.Helper()
+	e.mustRun("new", "h", "c", "1", "100", "")
+	e.gameID = e.session.GetCurrentGame()
+	return e.gameID
+}
+
+// playMoves makes moves in the current game, alternating sides, without
+// stopping at repetition warnings
+func (e *testEnv) playMoves(moves ...string) {
+	e.t.Helper()
+	for _, m := range moves {
+		e.mustRun("move " + m + " -y")
+	}
+}
+
+// moves returns the moves of a game as the fake server has them
+func (e *testEnv) moves(gameID string) []string {
+	e.t.Helper()
+	game, ok := e.fake.Game(gameID)
+	if !ok {
+		e.t.Fatalf("game %s not on the server", gameID)
+	}
+	return game.Moves
+}
+
+// setStdin feeds lines to handlers reading os.Stdin until restored
+func setStdin(t *testing.T, lines []string) (restore func()) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) > 0 {
+		w.WriteString(strings.Join(lines, "\n") + "\n")
+	}
+	w.Close()
+
+	old := os.Stdin
+	os.Stdin = r
+	return func() {
+		os.Stdin = old
+		r.Close()
+	}
+}
+
+// commandCase is one invocation of a command against a fresh fake server
+type commandCase struct {
+	name    string
+	setup   func(e *testEnv)
+	line    string
+	input   []string
+	wantErr string // substring of the expected error, "" for success
+	check   func(e *testEnv)
+}
+
+func runCases(t *testing.T, cases []commandCase) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := newTestEnv(t)
+			if tc.setup != nil {
+				tc.setup(e)
+			}
+			err := e.run(tc.line, tc.input...)
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Fatalf("%s: unexpected error: %v", tc.line, err)
+			case tc.wantErr != "" && err == nil:
+				t.Fatalf("%s: expected error containing %q", tc.line, tc.wantErr)
+			case tc.wantErr != "" && !strings.Contains(err.Error(), tc.wantErr):
+				t.Fatalf("%s: error %q does not contain %q", tc.line, err, tc.wantErr)
+			}
+			if tc.check != nil {
+				tc.check(e)
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,95 @@
+// FILE: lixenwraith/chess/internal/client/command/conformance.go
+package command
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"chess/internal/client/conformance"
+	"chess/internal/client/display"
+	"chess/internal/client/session"
+)
+
+func (r *Registry) registerConformanceCommands() {
+	r.Register(&Command{
+		Name:        "conformance",
+		ShortName:   "K",
+		Description: "Check a server against the API specification",
+		Usage:       "conformance [-url URL] [-junit file] [-slow] [-skip-auth]",
+		Handler:     conformanceHandler,
+	})
+}
+
+func conformanceHandler(s *session.Session, args []string) error {
+	cfg := conformance.Config{}
+	var junitPath string
+	fs := flag.NewFlagSet("conformance", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&cfg.BaseURL, "url", s.GetAPIBaseURL(), "server base URL")
+	fs.StringVar(&junitPath, "junit", "", "write a JUnit XML report to file")
+	fs.BoolVar(&cfg.Slow, "slow", false, "include checks that wait for the poll timeout")
+	fs.BoolVar(&cfg.SkipAuth, "skip-auth", false, "skip registration and login checks")
+	fs.DurationVar(&cfg.EngineTimeout, "engine-timeout", 15*time.Second, "how long to wait for computer moves")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%v\nusage: conformance [-url URL] [-junit file] [-slow] [-skip-auth]", err)
+	}
+	if s.IsOffline() && !isFlagSet(fs, "url") {
+		return fmt.Errorf("conformance checks need a server, use 'offline off' or -url")
+	}
+	if !strings.HasPrefix(cfg.BaseURL, "http://") && !strings.HasPrefix(cfg.BaseURL, "https://") {
+		cfg.BaseURL = "http://" + cfg.BaseURL
+	}
+
+	display.Println(display.Cyan, "Conformance checks against %s", cfg.BaseURL)
+	category := ""
+	report := conformance.Run(cfg, func(res conformance.Result) {
+		if res.Category != category {
+			category = res.Category
+			display.Println(display.Yellow, "%s:", category)
+		}
+		switch {
+		case res.Skipped:
+			fmt.Printf("  %s %s %s\n", display.C(display.Yellow, "SKIP"), res.Name, display.C(display.Blue, "("+res.Message+")"))
+		case res.Passed:
+			fmt.Printf("  %s %s %s\n", display.C(display.Green, "PASS"), res.Name, display.C(display.Blue, formatLatency(res.Duration)))
+		default:
+			fmt.Printf("  %s %s\n", display.C(display.Red, "FAIL"), res.Name)
+			display.Println(display.Red, "       %s", res.Message)
+		}
+	})
+
+	passed, failed, skipped := report.Counts()
+	summaryColor := display.Green
+	if failed > 0 {
+		summaryColor = display.Red
+	}
+	display.Println(summaryColor, "\n%d passed, %d failed, %d skipped in %s",
+		passed, failed, skipped, report.Elapsed.Round(time.Millisecond))
+
+	if junitPath != "" {
+		f, err := os.Create(junitPath)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %v", junitPath, err)
+		}
+		defer f.Close()
+		if err := report.WriteJUnit(f); err != nil {
+			return fmt.Errorf("failed to write %s: %v", junitPath, err)
+		}
+		display.Println(display.Green, "JUnit report written to %s", junitPath)
+	}
+	return nil
+}
+
+func isFlagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
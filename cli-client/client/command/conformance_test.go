@@ -0,0 +1,54 @@
+// FILE: lixenwraith/chess/internal/client/command/conformance_test.go
+package command
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConformanceCommand(t *testing.T) {
+	junitPath := filepath.Join(t.TempDir(), "conformance.xml")
+	runCases(t, []commandCase{
+		{
+			name: "fake server conforms",
+			// The poll check makes its move after half a second
+			setup: func(e *testEnv) { e.fake.SetPollTimeout(2 * time.Second) },
+			line:  "conformance -junit " + junitPath,
+			check: func(e *testEnv) {
+				data, err := os.ReadFile(junitPath)
+				if err != nil {
+					e.t.Fatal(err)
+				}
+				var report struct {
+					Tests    int `xml:"tests,attr"`
+					Failures int `xml:"failures,attr"`
+				}
+				if err := xml.Unmarshal(data, &report); err != nil {
+					e.t.Fatal(err)
+				}
+				if report.Tests == 0 || report.Failures != 0 {
+					e.t.Fatalf("%d checks, %d failures:\n%s", report.Tests, report.Failures, data)
+				}
+			},
+		},
+		{
+			name:  "explicit url while offline",
+			setup: func(e *testEnv) { e.mustRun("offline on") },
+			line:  "K -skip-auth -url {url}",
+		},
+		{
+			name:    "offline without url",
+			setup:   func(e *testEnv) { e.mustRun("offline on") },
+			line:    "conformance",
+			wantErr: "need a server",
+		},
+		{
+			name:    "unknown flag",
+			line:    "conformance -fast",
+			wantErr: "usage",
+		},
+	})
+}
\ No newline at end of file
@@ -6,8 +6,8 @@ import (
 	"strings"
 	"time"
 
-	"chess/internal/client/api"
 	"chess/internal/client/display"
+	"chess/internal/client/local"
 	"chess/internal/client/session"
 )
 
@@ -35,10 +35,18 @@ func (r *Registry) registerDebugCommands() {
 		Usage:       "raw <method> <path> [json-body]",
 		Handler:     rawRequestHandler,
 	})
+
+	r.Register(&Command{
+		Name:        "offline",
+		ShortName:   "f",
+		Description: "Play locally with the embedded engine",
+		Usage:       "offline [on|off]",
+		Handler:     offlineHandler,
+	})
 }
 
 func healthHandler(s *session.Session, args []string) error {
-	c := s.GetClient().(*api.Client)
+	c := s.GetBackend()
 	resp, err := c.Health()
 	if err != nil {
 		return err
@@ -67,8 +75,11 @@ func urlHandler(s *session.Session, args []string) error {
 		url = "http://" + url
 	}
 
+	c, ok := s.GetBackend().(session.Remote)
+	if !ok {
+		return fmt.Errorf("current backend has no server URL, use 'offline off' first")
+	}
 	s.SetAPIBaseURL(url)
-	c := s.GetClient().(*api.Client)
 	c.SetBaseURL(url)
 
 	display.Println(display.Cyan, "API URL set to: %s", url)
@@ -88,6 +99,44 @@ func rawRequestHandler(s *session.Session, args []string) error {
 		body = strings.Join(args[2:], " ")
 	}
 
-	c := s.GetClient().(*api.Client)
+	c, ok := s.GetBackend().(session.Remote)
+	if !ok {
+		return fmt.Errorf("raw requests need a server backend, use 'offline off' first")
+	}
 	return c.RawRequest(method, path, body)
+}
+
+func offlineHandler(s *session.Session, args []string) error {
+	if len(args) == 0 {
+		if s.IsOffline() {
+			fmt.Println("Mode: offline (local engine)")
+		} else {
+			fmt.Printf("Mode: online (%s)\n", s.GetAPIBaseURL())
+		}
+		return nil
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "on":
+		if s.IsOffline() {
+			display.Println(display.Yellow, "Already offline")
+			return nil
+		}
+		s.SetBackend(local.New())
+		display.Println(display.Cyan, "Offline mode: games are hosted in-process")
+	case "off":
+		if !s.IsOffline() {
+			display.Println(display.Yellow, "Already online")
+			return nil
+		}
+		s.SetBackend(nil)
+		display.Println(display.Cyan, "Online mode: API %s", s.GetAPIBaseURL())
+		display.Println(display.Yellow, "Offline games were discarded")
+	default:
+		return fmt.Errorf("usage: offline [on|off]")
+	}
+
+	// Game IDs are not shared between backends
+	s.ClearGame()
+	return nil
 }
\ No newline at end of file
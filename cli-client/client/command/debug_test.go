@@ -0,0 +1,131 @@
+// FILE: lixenwraith/chess/internal/client/command/debug_test.go
+package command
+
+import (
+	"testing"
+
+	"chess/internal/client/clienttest"
+)
+
+func TestHealthCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name: "healthy",
+			line: "health",
+		},
+		{
+			name: "server error",
+			setup: func(e *testEnv) {
+				e.fake.FailNext("GET /health", clienttest.Failure{Status: 503, Code: "UNAVAILABLE", Message: "down"})
+			},
+			line:    ".",
+			wantErr: "status 503",
+		},
+	})
+}
+
+func TestURLCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name: "show",
+			line: "url",
+		},
+		{
+			name: "scheme added",
+			line: "url localhost:1",
+			check: func(e *testEnv) {
+				if e.session.GetAPIBaseURL() != "http://localhost:1" {
+					e.t.Fatalf("url = %q", e.session.GetAPIBaseURL())
+				}
+				// Requests now go to the new address and fail
+				if err := e.run("health"); err == nil {
+					e.t.Fatal("health succeeded against a closed port")
+				}
+			},
+		},
+		{
+			name:    "offline backend",
+			setup:   func(e *testEnv) { e.mustRun("offline on") },
+			line:    "url localhost:1",
+			wantErr: "no server URL",
+		},
+	})
+}
+
+func TestRawCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name: "get",
+			line: "raw get /health",
+		},
+		{
+			name: "post with body",
+			line: `: POST /api/v1/games {"white":{"type":1},"black":{"type":1}}`,
+			check: func(e *testEnv) {
+				if e.fake.GameCount() != 1 {
+					e.t.Fatalf("game count = %d, want 1", e.fake.GameCount())
+				}
+			},
+		},
+		{
+			name:    "error status",
+			line:    "raw GET /api/v1/games/no-such-game",
+			wantErr: "status 404",
+		},
+		{
+			name:    "missing path",
+			line:    "raw GET",
+			wantErr: "usage",
+		},
+		{
+			name:    "offline backend",
+			setup:   func(e *testEnv) { e.mustRun("offline on") },
+			line:    "raw GET /health",
+			wantErr: "server backend",
+		},
+	})
+}
+
+func TestOfflineCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name: "status",
+			line: "offline",
+		},
+		{
+			name: "switch on and play",
+			line: "offline on",
+			check: func(e *testEnv) {
+				if !e.session.IsOffline() {
+					e.t.Fatal("session still online")
+				}
+				gameID := e.newGame()
+				e.mustRun("move e2e4")
+				if e.fake.GameCount() != 0 {
+					e.t.Fatal("offline game reached the server")
+				}
+				if state := e.session.GetGameState(); state == nil || state.GameID != gameID || len(state.Moves) != 1 {
+					e.t.Fatalf("offline game state = %+v", state)
+				}
+			},
+		},
+		{
+			name: "switch back off",
+			setup: func(e *testEnv) {
+				e.mustRun("offline on")
+				e.newGame()
+			},
+			line: "f off",
+			check: func(e *testEnv) {
+				if e.session.IsOffline() || e.session.GetCurrentGame() != "" {
+					e.t.Fatalf("offline %v, current game %q", e.session.IsOffline(), e.session.GetCurrentGame())
+				}
+			},
+		},
+		{
+			name:    "bad argument",
+			line:    "offline maybe",
+			wantErr: "usage",
+		},
+	})
+}
\ No newline at end of file
@@ -0,0 +1,340 @@
+// FILE: lixenwraith/chess/internal/client/command/draw.go
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"chess/internal/client/api"
+	"chess/internal/client/display"
+	"chess/internal/client/rules"
+	"chess/internal/client/session"
+)
+
+func (r *Registry) registerDrawCommands() {
+	r.Register(&Command{
+		Name:        "resign",
+		Description: "Resign the current game",
+		Usage:       "resign [w|b] [-y]",
+		Handler:     resignHandler,
+	})
+
+	r.Register(&Command{
+		Name:        "offer-draw",
+		Description: "Offer the opponent a draw",
+		Usage:       "offer-draw [w|b]",
+		Handler:     offerDrawHandler,
+	})
+
+	r.Register(&Command{
+		Name:        "accept",
+		Description: "Accept the opponent's draw offer",
+		Usage:       "accept",
+		Handler:     drawAnswerHandler(api.DrawAccept),
+	})
+
+	r.Register(&Command{
+		Name:        "decline",
+		Description: "Decline the opponent's draw offer",
+		Usage:       "decline",
+		Handler:     drawAnswerHandler(api.DrawDecline),
+	})
+
+	r.Register(&Command{
+		Name:        "claim",
+		Description: "Claim a draw by threefold repetition or the fifty-move rule",
+		Usage:       "claim [repetition | fifty] [w|b]",
+		Handler:     claimHandler,
+	})
+}
+
+// ongoingGame fetches the current game and checks it can still be ended
+func ongoingGame(s *session.Session) (*api.GameResponse, error) {
+	gameID := s.GetCurrentGame()
+	if gameID == "" {
+		return nil, fmt.Errorf("no current game, use 'new' or 'join <gameId>'")
+	}
+	if err := checkFlag(s, gameID); err != nil {
+		return nil, err
+	}
+	game, err := s.GetBackend().GetGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case game.State == api.StatePending:
+		return nil, fmt.Errorf("computer move in progress, wait for it first")
+	case game.State.IsOver():
+		return nil, fmt.Errorf("game is over: %s", game.Reason())
+	}
+	return game, nil
+}
+
+// actingSide picks the side an action is taken for: the one named, else
+// the user's seat, else the only human side, else the human side to move
+func actingSide(s *session.Session, game *api.GameResponse, named string) (api.Color, error) {
+	if named != "" {
+		color, err := api.ParseColor(named)
+		if err != nil {
+			return api.NoColor, err
+		}
+		if game.Players.Player(color).Type != api.Human {
+			return api.NoColor, fmt.Errorf("%s is played by the computer", color)
+		}
+		return color, nil
+	}
+	if color := s.GetPlayerColor(); color.Valid() {
+		return color, nil
+	}
+
+	white := game.Players.White.Type == api.Human
+	black := game.Players.Black.Type == api.Human
+	switch {
+	case white && !black:
+		return api.White, nil
+	case black && !white:
+		return api.Black, nil
+	case white && black:
+		return game.Turn, nil
+	}
+	return api.NoColor, fmt.Errorf("no human side in this game")
+}
+
+func resignHandler(s *session.Session, args []string) error {
+	confirmed := false
+	named := ""
+	for _, arg := range args {
+		if arg == "-y" {
+			confirmed = true
+		} else {
+			named = arg
+		}
+	}
+
+	game, err := ongoingGame(s)
+	if err != nil {
+		return err
+	}
+	color, err := actingSide(s, game, named)
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		display.Print(display.Yellow, "Resign as %s? (y/N): ", color)
+		scanner := bufio.NewScanner(os.Stdin)
+		scanner.Scan()
+		if answer := strings.ToLower(strings.TrimSpace(scanner.Text())); answer != "y" && answer != "yes" {
+			display.Println(display.Yellow, "Resignation cancelled")
+			return nil
+		}
+	}
+
+	resp, err := s.GetBackend().Resign(game.GameID, color)
+	if err != nil {
+		return err
+	}
+	s.UpdateGame(resp)
+	printGameOver(resp)
+	return nil
+}
+
+func offerDrawHandler(s *session.Session, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("usage: offer-draw [w|b]")
+	}
+	game, err := ongoingGame(s)
+	if err != nil {
+		return err
+	}
+	color, err := actingSide(s, game, strings.Join(args, ""))
+	if err != nil {
+		return err
+	}
+	if game.DrawOffer == color.Other() {
+		return fmt.Errorf("%s has already offered a draw, use 'accept'", color.Other())
+	}
+
+	resp, err := s.GetBackend().Draw(game.GameID, &api.DrawRequest{Action: api.DrawOffer, Color: color})
+	if err != nil {
+		return err
+	}
+	s.UpdateGame(resp)
+
+	switch {
+	case resp.State.IsOver():
+		printGameOver(resp)
+	case resp.DrawOffer == color:
+		display.Println(display.Green, "%s offers a draw, %s can 'accept' or 'decline'", color, color.Other())
+	default:
+		// A computer opponent answers at once
+		display.Println(display.Yellow, "%s declines the draw", color.Other())
+	}
+	return nil
+}
+
+// drawAnswerHandler accepts or declines the draw offered to the user
+func drawAnswerHandler(action api.DrawAction) func(*session.Session, []string) error {
+	return func(s *session.Session, args []string) error {
+		game, err := ongoingGame(s)
+		if err != nil {
+			return err
+		}
+		if !game.DrawOffer.Valid() {
+			return fmt.Errorf("no draw offer standing")
+		}
+		color := game.DrawOffer.Other()
+		if mine := s.GetPlayerColor(); mine.Valid() && mine != color {
+			return fmt.Errorf("the draw offer is yours, %s has to answer it", color)
+		}
+
+		resp, err := s.GetBackend().Draw(game.GameID, &api.DrawRequest{Action: action, Color: color})
+		if err != nil {
+			return err
+		}
+		s.UpdateGame(resp)
+		if resp.State.IsOver() {
+			printGameOver(resp)
+		} else {
+			display.Println(display.Yellow, "%s declines the draw, play on", color)
+		}
+		return nil
+	}
+}
+
+func claimHandler(s *session.Session, args []string) error {
+	var reason api.Termination
+	named := ""
+	for _, arg := range args {
+		switch arg {
+		case "repetition", "threefold":
+			reason = api.TermRepetition
+		case "fifty", "50":
+			reason = api.TermFiftyMoves
+		default:
+			named = arg
+		}
+	}
+
+	game, err := ongoingGame(s)
+	if err != nil {
+		return err
+	}
+	color, err := actingSide(s, game, named)
+	if err != nil {
+		return err
+	}
+
+	// Check eligibility locally before asking the server
+	claims := drawClaims(s, game)
+	if reason == "" {
+		for _, r := range []api.Termination{api.TermRepetition, api.TermFiftyMoves} {
+			if claims[r].ok {
+				reason = r
+				break
+			}
+		}
+		if reason == "" {
+			return fmt.Errorf("no draw to claim: %s; %s", claims[api.TermRepetition].detail, claims[api.TermFiftyMoves].detail)
+		}
+	} else if !claims[reason].ok {
+		return fmt.Errorf("no draw by %s: %s", reason, claims[reason].detail)
+	}
+
+	resp, err := s.GetBackend().Draw(game.GameID, &api.DrawRequest{Action: api.DrawClaim, Color: color, Reason: reason})
+	if err != nil {
+		return err
+	}
+	s.UpdateGame(resp)
+	printGameOver(resp)
+	return nil
+}
+
+// drawClaim is whether a draw rule applies to the current position
+type drawClaim struct {
+	ok     bool
+	detail string
+}
+
+// drawClaims checks the repetition count of the position, replaying the
+// move history, and the FEN halfmove clock
+func drawClaims(s *session.Session, game *api.GameResponse) map[api.Termination]drawClaim {
+	claims := map[api.Termination]drawClaim{
+		api.TermRepetition: {detail: "move history cannot be replayed"},
+		api.TermFiftyMoves: {detail: "halfmove clock unknown"},
+	}
+
+	if pos, err := rules.ParseFEN(game.FEN); err == nil {
+		claims[api.TermFiftyMoves] = drawClaim{
+			ok:     pos.Halfmove >= rules.ClaimPlies,
+			detail: fmt.Sprintf("halfmove clock %d/%d", pos.Halfmove, rules.ClaimPlies),
+		}
+	}
+
+	if history := s.Replay(game); history != nil {
+		n := history.Repetitions()
+		claims[api.TermRepetition] = drawClaim{
+			ok:     n >= 3,
+			detail: fmt.Sprintf("position seen %d time(s)", n),
+		}
+	}
+	return claims
+}
+
+// printDrawCounters shows how often the position has occurred and the
+// halfmove clock, highlighted when a draw claim is near
+func printDrawCounters(s *session.Session, game *api.GameResponse) {
+	if history := s.Replay(game); history != nil {
+		n := history.Repetitions()
+		color := display.White
+		if n >= 2 {
+			color = display.Yellow
+		}
+		display.Print(color, "Repetitions: %d", n)
+		fmt.Print(" | ")
+	}
+	if pos, err := rules.ParseFEN(game.FEN); err == nil {
+		color := display.White
+		if pos.Halfmove >= rules.WarnPlies {
+			color = display.Yellow
+		}
+		display.Print(color, "Halfmove clock: %d/%d", pos.Halfmove, rules.ClaimPlies)
+	}
+	fmt.Println()
+}
+
+// confirmRepetition warns before a move that lets the opponent claim a
+// draw by threefold repetition, returning false if the user backs out
+func confirmRepetition(s *session.Session, gameID, uciMove string) bool {
+	game := s.GetGameState()
+	if game == nil || game.GameID != gameID || game.State != api.StateOngoing {
+		return true
+	}
+	history := s.Replay(game)
+	if history == nil {
+		return true
+	}
+	m, err := history.Position().ParseMove(uciMove)
+	if err != nil {
+		// Left to the server to reject
+		return true
+	}
+	claim, reply := history.RepetitionClaim(m)
+	if !claim {
+		return true
+	}
+
+	opponent := api.Color(history.Position().Turn).Other()
+	if reply == nil {
+		display.Println(display.Yellow, "%s repeats the position a third time, %s can claim a draw", uciMove, opponent)
+	} else {
+		display.Println(display.Yellow, "After %s, %s can claim a draw by threefold repetition with %s", uciMove, opponent, reply)
+	}
+	display.Print(display.Yellow, "Play %s anyway? (y/N): ", uciMove)
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Scan()
+	answer := strings.ToLower(strings.TrimSpace(scanner.Text()))
+	return answer == "y" || answer == "yes"
+}
\ No newline at end of file
@@ -0,0 +1,319 @@
+// FILE: lixenwraith/chess/internal/client/command/draw_test.go
+package command
+
+import (
+	"sync"
+	"testing"
+
+	"chess/internal/client/api"
+)
+
+// shuffle returns to the start position every four plies, for the third
+// time after eight
+var shuffle = []string{"g1f3", "g8f6", "f3g1", "f6g8", "g1f3", "g8f6", "f3g1", "f6g8"}
+
+// afterPush repeats the position after 1. e4, whose en passant square no
+// capture can use, for the third time after nine plies
+var afterPush = []string{"e2e4", "g8f6", "g1f3", "f6g8", "f3g1", "g8f6", "g1f3", "f6g8", "f3g1"}
+
+// ended checks the game finished with the state and termination given, as
+// the session last saw it and, for server games, on the fake server
+func ended(state api.GameState, term api.Termination) func(e *testEnv) {
+	return func(e *testEnv) {
+		games := []*api.GameResponse{e.session.GetGameState()}
+		if game, ok := e.fake.Game(e.gameID); ok {
+			games = append(games, game)
+		}
+		for _, game := range games {
+			if game == nil || game.State != state || game.Termination != term {
+				e.t.Fatalf("game %+v, want %s by %q", game, state, term)
+			}
+		}
+	}
+}
+
+// ongoing checks the game has not finished
+func ongoing(e *testEnv) {
+	ended(api.StateOngoing, "")(e)
+}
+
+// newGameAt creates a human versus human game from a position
+func (e *testEnv) newGameAt(fen string) {
+	e.t.Helper()
+	e.mustRun("new", "h", "h", fen)
+	e.gameID = e.session.GetCurrentGame()
+}
+
+func TestResignCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name:  "confirmed",
+			setup: func(e *testEnv) { e.newGame() },
+			line:  "resign",
+			input: []string{"y"},
+			check: ended(api.StateBlackWins, api.TermResignation),
+		},
+		{
+			name:  "named side without prompt",
+			setup: func(e *testEnv) { e.newGame() },
+			line:  "resign b -y",
+			check: ended(api.StateWhiteWins, api.TermResignation),
+		},
+		{
+			name:  "cancelled",
+			setup: func(e *testEnv) { e.newGame() },
+			line:  "resign",
+			input: []string{"n"},
+			check: ongoing,
+		},
+		{
+			name:    "computer side",
+			setup:   func(e *testEnv) { e.newComputerGame() },
+			line:    "resign b -y",
+			wantErr: "played by the computer",
+		},
+		{
+			name:    "game over",
+			setup:   func(e *testEnv) { e.newGame(); e.mustRun("resign -y") },
+			line:    "resign -y",
+			wantErr: "game is over",
+		},
+		{
+			name:    "no current game",
+			line:    "resign -y",
+			wantErr: "no current game",
+		},
+	})
+}
+
+// TestOfflineDrawOfferConcurrent reads the game while the computer
+// considers draw offers, which it does without holding the backend lock
+func TestOfflineDrawOfferConcurrent(t *testing.T) {
+	e := newTestEnv(t)
+	e.mustRun("offline on")
+	gameID := e.newComputerGame()
+	c := e.session.GetBackend()
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if _, err := c.GetGame(gameID); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+
+	game, err := c.Draw(gameID, &api.DrawRequest{Action: api.DrawOffer, Color: api.White})
+	close(done)
+	wg.Wait()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if game.State != api.StateDraw {
+		t.Fatalf("state = %s, want the even game drawn", game.State)
+	}
+}
+
+func TestOfferDrawCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name:  "offer stands",
+			setup: func(e *testEnv) { e.newGame() },
+			line:  "offer-draw",
+			check: func(e *testEnv) {
+				if game, _ := e.fake.Game(e.gameID); game.DrawOffer != api.White {
+					e.t.Fatalf("draw offer by %q, want white", game.DrawOffer)
+				}
+			},
+		},
+		{
+			name:  "computer takes an even game",
+			setup: func(e *testEnv) { e.newComputerGame() },
+			line:  "offer-draw",
+			check: ended(api.StateDraw, api.TermAgreement),
+		},
+		{
+			name:  "computer takes an even game offline",
+			setup: func(e *testEnv) { e.mustRun("offline on"); e.newComputerGame() },
+			line:  "offer-draw",
+			check: ended(api.StateDraw, api.TermAgreement),
+		},
+		{
+			name:    "answer instead of counter-offer",
+			setup:   func(e *testEnv) { e.newGame(); e.mustRun("offer-draw w") },
+			line:    "offer-draw b",
+			wantErr: "use 'accept'",
+		},
+		{
+			name:    "too many arguments",
+			setup:   func(e *testEnv) { e.newGame() },
+			line:    "offer-draw w b",
+			wantErr: "usage",
+		},
+	})
+}
+
+func TestAcceptCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name:  "draw agreed",
+			setup: func(e *testEnv) { e.newGame(); e.mustRun("offer-draw") },
+			line:  "accept",
+			check: ended(api.StateDraw, api.TermAgreement),
+		},
+		{
+			name:    "no offer",
+			setup:   func(e *testEnv) { e.newGame() },
+			line:    "accept",
+			wantErr: "no draw offer",
+		},
+	})
+}
+
+func TestDeclineCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name:  "play on",
+			setup: func(e *testEnv) { e.newGame(); e.mustRun("offer-draw") },
+			line:  "decline",
+			check: func(e *testEnv) {
+				ongoing(e)
+				if game, _ := e.fake.Game(e.gameID); game.DrawOffer.Valid() {
+					e.t.Fatalf("draw offer by %s still standing", game.DrawOffer)
+				}
+			},
+		},
+		{
+			name:    "no offer",
+			setup:   func(e *testEnv) { e.newGame() },
+			line:    "decline",
+			wantErr: "no draw offer",
+		},
+	})
+}
+
+func TestClaimCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name:  "threefold repetition",
+			setup: func(e *testEnv) { e.newGame(); e.playMoves(shuffle...) },
+			line:  "claim",
+			check: ended(api.StateDraw, api.TermRepetition),
+		},
+		{
+			name:  "threefold repetition offline",
+			setup: func(e *testEnv) { e.mustRun("offline on"); e.newGame(); e.playMoves(shuffle...) },
+			line:  "claim repetition",
+			check: ended(api.StateDraw, api.TermRepetition),
+		},
+		{
+			name:  "fifty-move rule",
+			setup: func(e *testEnv) { e.newGameAt("4k3/8/8/8/8/8/8/4K2R w - - 99 80"); e.mustRun("move h1h2") },
+			line:  "claim fifty",
+			check: ended(api.StateDraw, api.TermFiftyMoves),
+		},
+		{
+			name: "fifty-move rule offline",
+			setup: func(e *testEnv) {
+				e.mustRun("offline on")
+				e.newGameAt("4k3/8/8/8/8/8/8/4K2R w - - 99 80")
+				e.mustRun("move h1h2")
+			},
+			line:  "claim",
+			check: ended(api.StateDraw, api.TermFiftyMoves),
+		},
+		{
+			name:  "repetition of the position after a double pawn push",
+			setup: func(e *testEnv) { e.newGame(); e.playMoves(afterPush...) },
+			line:  "claim repetition",
+			check: ended(api.StateDraw, api.TermRepetition),
+		},
+		{
+			name: "repetition after a double pawn push offline",
+			setup: func(e *testEnv) {
+				e.mustRun("offline on")
+				e.newGame()
+				e.playMoves(afterPush...)
+			},
+			line:  "claim repetition",
+			check: ended(api.StateDraw, api.TermRepetition),
+		},
+		{
+			name:    "repetition not reached",
+			setup:   func(e *testEnv) { e.newGame(); e.playMoves(shuffle[:4]...) },
+			line:    "claim repetition",
+			wantErr: "seen 2 time(s)",
+		},
+		{
+			name:    "fifty moves not reached",
+			setup:   func(e *testEnv) { e.newGame(); e.mustRun("move e2e4") },
+			line:    "claim fifty",
+			wantErr: "halfmove clock 0/100",
+		},
+		{
+			name:    "nothing to claim",
+			setup:   func(e *testEnv) { e.newGame() },
+			line:    "claim",
+			wantErr: "no draw to claim",
+		},
+	})
+}
+
+// TestAutomaticDraws checks both backends end games only by the rules that
+// need no claim
+func TestAutomaticDraws(t *testing.T) {
+	var cases []commandCase
+	for _, backend := range []string{"server", "offline"} {
+		online := func(e *testEnv) {
+			if backend == "offline" {
+				e.mustRun("offline on")
+			}
+		}
+		cases = append(cases,
+			commandCase{
+				name:  backend + " threefold repetition waits for a claim",
+				setup: func(e *testEnv) { online(e); e.newGame(); e.playMoves(shuffle[:7]...) },
+				line:  "move f6g8 -y",
+				check: ongoing,
+			},
+			commandCase{
+				name:  backend + " fifty-move rule waits for a claim",
+				setup: func(e *testEnv) { online(e); e.newGameAt("4k3/8/8/8/8/8/8/4K2R w - - 99 80") },
+				line:  "move h1h2",
+				check: ongoing,
+			},
+			commandCase{
+				name: backend + " fivefold repetition",
+				setup: func(e *testEnv) {
+					online(e)
+					e.newGame()
+					e.playMoves(append(append([]string{}, shuffle...), shuffle[:7]...)...)
+				},
+				line:  "move f6g8 -y",
+				check: ended(api.StateDraw, api.TermFivefold),
+			},
+			commandCase{
+				name:  backend + " seventy-five-move rule",
+				setup: func(e *testEnv) { online(e); e.newGameAt("4k3/8/8/8/8/8/8/4K2R w - - 149 100") },
+				line:  "move h1h2",
+				check: ended(api.StateDraw, api.TermSeventyFive),
+			},
+			commandCase{
+				name:  backend + " insufficient material",
+				setup: func(e *testEnv) { online(e); e.newGameAt("4k3/8/8/8/8/8/3r4/4K3 w - - 0 1") },
+				line:  "move e1d2",
+				check: ended(api.StateDraw, api.TermInsufficient),
+			},
+		)
+	}
+	runCases(t, cases)
+}
\ No newline at end of file
@@ -0,0 +1,281 @@
+// FILE: lixenwraith/chess/internal/client/command/edit.go
+package command
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"chess/internal/client/api"
+	"chess/internal/client/display"
+	"chess/internal/client/rules"
+	"chess/internal/client/session"
+)
+
+const editHelp = `  put <piece><square>...   place pieces, FEN letters: Qd4 white queen, ke8 black king
+  clear <square>... | all  remove pieces or empty the board
+  castling <KQkq | ->      set castling rights
+  ep <square | ->          set the en passant target square
+  side <w | b>             set the side to move
+  fen [<fen>]              print the FEN or load a new one
+  start                    load the standard start position
+  done                     validate and start a game from the position
+  cancel                   leave the editor without creating a game`
+
+func editHandler(s *session.Session, args []string) error {
+	pos := emptyPosition()
+	switch {
+	case len(args) == 0, len(args) == 1 && args[0] == "empty":
+	case len(args) == 1 && args[0] == "start":
+		pos = rules.Start()
+	default:
+		p, err := rules.ParseFEN(strings.Join(args, " "))
+		if err != nil {
+			return fenError(err)
+		}
+		pos = p
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	display.Println(display.Cyan, "\nPosition editor, type 'help' for commands")
+	showEditPosition(pos)
+
+	for {
+		display.Print(display.Yellow, "edit> ")
+		if !scanner.Scan() {
+			return nil
+		}
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "help", "?":
+			fmt.Println(editHelp)
+			continue
+
+		case "cancel", "quit", "exit":
+			display.Println(display.Yellow, "Editor closed, no game created")
+			return nil
+
+		case "done":
+			if err := pos.Validate(); err != nil {
+				display.Println(display.Red, "Error: %s", fenError(err).Error())
+				continue
+			}
+			if status := pos.Status(); status != rules.Ongoing {
+				display.Println(display.Red, "Error: position is already over by %s", status)
+				continue
+			}
+			white, err := promptPlayer(scanner, "White")
+			if err != nil {
+				return err
+			}
+			black, err := promptPlayer(scanner, "Black")
+			if err != nil {
+				return err
+			}
+			tc, err := promptTimeControl(scanner)
+			if err != nil {
+				return err
+			}
+			return startGame(s, &api.CreateGameRequest{
+				White: white,
+				Black: black,
+				FEN:   pos.FEN(),
+			}, tc)
+		}
+
+		next, err := editPosition(pos, fields)
+		if err != nil {
+			display.Println(display.Red, "Error: %s", err.Error())
+			continue
+		}
+		if next != nil {
+			pos = next
+			showEditPosition(pos)
+		}
+	}
+}
+
+// importHandler starts a game from a FEN given on the command line or
+// asked for, after checking it field by field
+func importHandler(s *session.Session, args []string) error {
+	scanner := bufio.NewScanner(os.Stdin)
+	fen := strings.Join(args, " ")
+	if fen == "" {
+		display.Print(display.Yellow, "FEN: ")
+		scanner.Scan()
+		fen = strings.TrimSpace(scanner.Text())
+	}
+	if fen == "" {
+		return fmt.Errorf("usage: import <fen>")
+	}
+
+	pos, err := rules.CheckFEN(fen)
+	if err != nil {
+		return fenError(err)
+	}
+	if status := pos.Status(); status != rules.Ongoing {
+		return fmt.Errorf("position is already over by %s", status)
+	}
+	showEditPosition(pos)
+
+	white, err := promptPlayer(scanner, "White")
+	if err != nil {
+		return err
+	}
+	black, err := promptPlayer(scanner, "Black")
+	if err != nil {
+		return err
+	}
+	tc, err := promptTimeControl(scanner)
+	if err != nil {
+		return err
+	}
+	return startGame(s, &api.CreateGameRequest{
+		White: white,
+		Black: black,
+		FEN:   pos.FEN(),
+	}, tc)
+}
+
+// editPosition applies one editor command. It returns the edited position,
+// or nil when the command only printed something
+func editPosition(pos *rules.Position, fields []string) (*rules.Position, error) {
+	cmd, args := fields[0], fields[1:]
+	next := *pos
+
+	switch cmd {
+	case "put":
+		if len(args) == 0 {
+			return nil, fmt.Errorf("usage: put <piece><square>..., e.g. put Qd4 ke8")
+		}
+		for _, arg := range args {
+			if len(arg) != 3 || !strings.ContainsRune("pnbrqkPNBRQK", rune(arg[0])) {
+				return nil, fmt.Errorf("invalid placement %q, expected a piece letter and square like Qd4", arg)
+			}
+			sq, ok := rules.ParseSquare(arg[1:])
+			if !ok {
+				return nil, fmt.Errorf("invalid square %q", arg[1:])
+			}
+			next.Board[sq] = arg[0]
+		}
+
+	case "clear":
+		if len(args) == 0 {
+			return nil, fmt.Errorf("usage: clear <square>... | all")
+		}
+		if len(args) == 1 && args[0] == "all" {
+			next.Board = [64]byte{}
+			next.Castling = 0
+			next.EnPassant = rules.NoSquare
+			break
+		}
+		for _, arg := range args {
+			sq, ok := rules.ParseSquare(arg)
+			if !ok {
+				return nil, fmt.Errorf("invalid square %q", arg)
+			}
+			next.Board[sq] = 0
+		}
+
+	case "castling":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("usage: castling <KQkq | ->")
+		}
+		rights, err := rules.ParseCastling(args[0])
+		if err != nil {
+			return nil, err
+		}
+		next.Castling = rights
+
+	case "ep":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("usage: ep <square | ->")
+		}
+		if args[0] == "-" {
+			next.EnPassant = rules.NoSquare
+			break
+		}
+		sq, ok := rules.ParseSquare(args[0])
+		if !ok {
+			return nil, fmt.Errorf("invalid square %q", args[0])
+		}
+		next.EnPassant = sq
+
+	case "side":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("usage: side <w | b>")
+		}
+		c, err := api.ParseColor(args[0])
+		if err != nil {
+			return nil, err
+		}
+		next.Turn = rules.Color(c[0])
+
+	case "fen":
+		if len(args) == 0 {
+			fmt.Println(pos.FEN())
+			return nil, nil
+		}
+		p, err := rules.ParseFEN(strings.Join(args, " "))
+		if err != nil {
+			return nil, fenError(err)
+		}
+		return p, nil
+
+	case "start":
+		return rules.Start(), nil
+
+	case "empty":
+		return emptyPosition(), nil
+
+	default:
+		return nil, fmt.Errorf("unknown editor command %q, type 'help' for commands", cmd)
+	}
+	return &next, nil
+}
+
+// emptyPosition returns a board without pieces, white to move
+func emptyPosition() *rules.Position {
+	return &rules.Position{Turn: rules.White, EnPassant: rules.NoSquare, Fullmove: 1}
+}
+
+func showEditPosition(pos *rules.Position) {
+	fmt.Println()
+	display.RenderBoard(pos.ASCII())
+	fields := strings.Fields(pos.FEN())
+	fmt.Printf("\nTurn: %s | Castling: %s | En passant: %s\n",
+		display.ColorForTurn(fields[1]), fields[2], fields[3])
+	fmt.Printf("FEN: %s\n", pos.FEN())
+	if err := pos.Validate(); err != nil {
+		display.Println(display.Yellow, "Not playable yet:")
+		printFENErrors(err)
+	}
+}
+
+// fenError prints FEN diagnostics one per line and returns a short error
+// for the caller to report
+func fenError(err error) error {
+	var errs rules.FENErrors
+	if !errors.As(err, &errs) {
+		return err
+	}
+	printFENErrors(errs)
+	return fmt.Errorf("FEN rejected, %d problem(s) found", len(errs))
+}
+
+func printFENErrors(err error) {
+	var errs rules.FENErrors
+	if !errors.As(err, &errs) {
+		display.Println(display.Yellow, "  %s", err.Error())
+		return
+	}
+	for _, e := range errs {
+		display.Println(display.Yellow, "  %s", e.Error())
+	}
+}
\ No newline at end of file
@@ -0,0 +1,111 @@
+// FILE: lixenwraith/chess/internal/client/command/edit_test.go
+package command
+
+import "testing"
+
+func TestEditCommand(t *testing.T) {
+	noGame := func(e *testEnv) {
+		if e.fake.GameCount() != 0 {
+			e.t.Fatalf("%d games created", e.fake.GameCount())
+		}
+	}
+
+	runCases(t, []commandCase{
+		{
+			name:  "build and start",
+			line:  "edit",
+			input: []string{"put Ke1 ke8 Ra1", "castling Q", "bogus", "done", "h", "h"},
+			check: func(e *testEnv) {
+				game, ok := e.fake.Game(e.session.GetCurrentGame())
+				if !ok || game.FEN != "4k3/8/8/8/8/8/8/R3K3 w Q - 0 1" {
+					e.t.Fatalf("game = %+v", game)
+				}
+			},
+		},
+		{
+			name:  "from fen, black to move",
+			line:  "E 4k3/8/8/8/8/8/8/4K3 w - - 0 1",
+			input: []string{"put Qd4", "side b", "done", "h", "h"},
+			check: func(e *testEnv) {
+				game, ok := e.fake.Game(e.session.GetCurrentGame())
+				if !ok || game.FEN != "4k3/8/8/8/3Q4/8/8/4K3 b - - 0 1" {
+					e.t.Fatalf("game = %+v", game)
+				}
+			},
+		},
+		{
+			name:  "invalid position is not started",
+			line:  "edit",
+			input: []string{"put Ke1", "done", "cancel"},
+			check: noGame,
+		},
+		{
+			name:  "end of input",
+			line:  "edit start",
+			input: []string{"clear e2"},
+			check: noGame,
+		},
+		{
+			name:    "bad fen argument",
+			line:    "edit not a fen",
+			wantErr: "FEN",
+		},
+	})
+}
+
+func TestImportCommand(t *testing.T) {
+	noGame := func(e *testEnv) {
+		if e.fake.GameCount() != 0 {
+			e.t.Fatalf("%d games created", e.fake.GameCount())
+		}
+	}
+
+	runCases(t, []commandCase{
+		{
+			name:  "fen argument",
+			line:  "import 4k3/8/8/8/8/8/8/R3K3 b Q - 3 20",
+			input: []string{"h", "h"},
+			check: func(e *testEnv) {
+				game, ok := e.fake.Game(e.session.GetCurrentGame())
+				if !ok || game.FEN != "4k3/8/8/8/8/8/8/R3K3 b Q - 3 20" {
+					e.t.Fatalf("game = %+v", game)
+				}
+			},
+		},
+		{
+			name:  "fen prompted",
+			line:  "I",
+			input: []string{"4k3/8/8/8/8/8/8/4K2R w K - 0 1", "h", "c", "2", "100"},
+			check: func(e *testEnv) {
+				game, ok := e.fake.Game(e.session.GetCurrentGame())
+				if !ok || game.FEN != "4k3/8/8/8/8/8/8/4K2R w K - 0 1" || game.Players.Black.Type != 2 {
+					e.t.Fatalf("game = %+v", game)
+				}
+			},
+		},
+		{
+			name:    "problems reported per field",
+			line:    "import 4k3/8/8/8/8/8/8/R3X3 w Q - x 1",
+			wantErr: "2 problem(s)",
+			check:   noGame,
+		},
+		{
+			name:    "illegal position",
+			line:    "import 4k3/8/8/8/8/8/8/K3K3 w - - 0 1",
+			wantErr: "FEN rejected",
+			check:   noGame,
+		},
+		{
+			name:    "finished position",
+			line:    "import k7/1Q6/1K6/8/8/8/8/8 b - - 0 1",
+			wantErr: "already over",
+			check:   noGame,
+		},
+		{
+			name:    "no fen",
+			line:    "import",
+			wantErr: "usage",
+			check:   noGame,
+		},
+	})
+}
\ No newline at end of file
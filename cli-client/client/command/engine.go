@@ -0,0 +1,239 @@
+// FILE: lixenwraith/chess/internal/client/command/engine.go
+package command
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/display"
+	"chess/internal/client/session"
+	"chess/internal/client/uci"
+)
+
+// enginePlayLimit bounds the plies 'engine play' makes unattended
+const enginePlayLimit = 500
+
+const engineUsage = "engine attach [-movetime MS] [-option Name=Value]... <w|b> <path> [args...] | engine detach | engine play [-max N] | engine"
+
+func (r *Registry) registerEngineCommands() {
+	r.Register(&Command{
+		Name:        "engine",
+		ShortName:   "U",
+		Description: "Let a local UCI engine play one side of the game",
+		Usage:       engineUsage,
+		Handler:     engineHandler,
+	})
+}
+
+func engineHandler(s *session.Session, args []string) error {
+	if len(args) == 0 {
+		return engineStatus(s)
+	}
+	switch args[0] {
+	case "attach":
+		return engineAttach(s, args[1:])
+	case "detach":
+		seat := s.GetEngineSeat()
+		if seat == nil {
+			return fmt.Errorf("no engine attached")
+		}
+		s.SetEngineSeat(nil)
+		seat.Engine.Close()
+		display.Println(display.Green, "%s detached", seat.Engine.Name)
+		return nil
+	case "play":
+		return enginePlay(s, args[1:])
+	}
+	return fmt.Errorf("usage: %s", engineUsage)
+}
+
+func engineStatus(s *session.Session) error {
+	seat := s.GetEngineSeat()
+	if seat == nil {
+		display.Println(display.Yellow, "No engine attached")
+		return nil
+	}
+	fmt.Printf("Engine:    %s (%s)\n", seat.Engine.Name, seat.Engine.Path)
+	fmt.Printf("Plays:     %s in game %s\n", seat.Color, seat.GameID)
+	fmt.Printf("Move time: %s\n", seat.MoveTime)
+	return nil
+}
+
+func engineAttach(s *session.Session, args []string) error {
+	var moveTime int
+	var options []string
+	fs := flag.NewFlagSet("engine attach", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.IntVar(&moveTime, "movetime", 1000, "engine time per move in milliseconds")
+	fs.Func("option", "engine option as Name=Value, repeatable", func(v string) error {
+		if !strings.Contains(v, "=") {
+			return fmt.Errorf("option must be Name=Value")
+		}
+		options = append(options, v)
+		return nil
+	})
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%v\nusage: %s", err, engineUsage)
+	}
+	if fs.NArg() < 2 {
+		return fmt.Errorf("usage: %s", engineUsage)
+	}
+	if moveTime < 1 {
+		return fmt.Errorf("movetime must be positive")
+	}
+	color, err := api.ParseColor(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	gameID := s.GetCurrentGame()
+	if gameID == "" {
+		return fmt.Errorf("no current game, use 'new' or 'join <gameId>'")
+	}
+	game, err := s.GetBackend().GetGame(gameID)
+	if err != nil {
+		return err
+	}
+	if game.Players.Player(color).Type != api.Human {
+		return fmt.Errorf("%s is played by the server computer, the engine needs a human side", color)
+	}
+
+	eng, err := uci.Start(fs.Arg(1), fs.Args()[2:]...)
+	if err != nil {
+		return err
+	}
+	for _, opt := range options {
+		name, value, _ := strings.Cut(opt, "=")
+		if err := eng.SetOption(name, value); err != nil {
+			eng.Close()
+			return err
+		}
+	}
+	if err := eng.NewGame(); err != nil {
+		eng.Close()
+		return err
+	}
+
+	if old := s.GetEngineSeat(); old != nil {
+		old.Engine.Close()
+	}
+	seat := &session.EngineSeat{
+		Engine:   eng,
+		GameID:   gameID,
+		Color:    color,
+		MoveTime: time.Duration(moveTime) * time.Millisecond,
+	}
+	s.SetEngineSeat(seat)
+	s.UpdateGame(game)
+	display.Println(display.Green, "%s plays %s in game %s", eng.Name, color, gameID)
+
+	return engineTurn(s)
+}
+
+// enginePlay runs the game unattended, triggering server computer moves and
+// engine moves in turn until it ends or a human is to move
+func enginePlay(s *session.Session, args []string) error {
+	var limit int
+	fs := flag.NewFlagSet("engine play", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.IntVar(&limit, "max", enginePlayLimit, "stop after this many plies")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%v\nusage: engine play [-max N]", err)
+	}
+
+	seat := s.GetEngineSeat()
+	if seat == nil {
+		return fmt.Errorf("no engine attached, use 'engine attach'")
+	}
+	if s.GetCurrentGame() != seat.GameID {
+		return fmt.Errorf("%s plays game %s, join it first", seat.Engine.Name, seat.GameID)
+	}
+
+	c := s.GetBackend()
+	for ply := 0; ply < limit; ply++ {
+		game, err := c.GetGame(seat.GameID)
+		if err != nil {
+			return err
+		}
+		s.UpdateGame(game)
+		if game.State.IsOver() {
+			return nil
+		}
+
+		switch {
+		case game.Turn == seat.Color:
+			err = engineMove(s, seat)
+		case game.Players.Player(game.Turn).Type == api.Computer:
+			err = computerMoveHandler(s, nil)
+		default:
+			display.Println(display.Cyan, "%s to move, your turn", game.Turn)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	display.Println(display.Yellow, "Stopped after %d plies", limit)
+	return nil
+}
+
+// engineTurn lets the attached engine move when the last known state of
+// its game has it to move
+func engineTurn(s *session.Session) error {
+	seat := s.GetEngineSeat()
+	if seat == nil || s.GetCurrentGame() != seat.GameID {
+		return nil
+	}
+	game := s.GetGameState()
+	if game == nil || game.GameID != seat.GameID || game.State != api.StateOngoing || game.Turn != seat.Color {
+		return nil
+	}
+	return engineMove(s, seat)
+}
+
+// engineMove asks the engine for its move and submits it
+func engineMove(s *session.Session, seat *session.EngineSeat) error {
+	if err := checkFlag(s, seat.GameID); err != nil {
+		return err
+	}
+	c := s.GetBackend()
+	game, err := c.GetGame(seat.GameID)
+	if err != nil {
+		return err
+	}
+	if game.State != api.StateOngoing || game.Turn != seat.Color {
+		return nil
+	}
+
+	// Send the whole game when it replays from its start position, so the
+	// engine sees repetitions; otherwise only the current position
+	fen, moves := game.FEN, []string(nil)
+	if history := s.Replay(game); history != nil {
+		fen, moves = history.Positions()[0].FEN(), game.Moves
+	}
+
+	display.Println(display.Magenta, "%s is thinking...", seat.Engine.Name)
+	info, err := seat.Engine.BestMove(fen, moves, seat.MoveTime)
+	if err != nil {
+		return err
+	}
+	resp, err := c.MakeMove(seat.GameID, info.Move)
+	if err != nil {
+		return fmt.Errorf("%s played %s: %w", seat.Engine.Name, info.Move, err)
+	}
+	s.UpdateGame(resp)
+
+	if info.Depth > 0 {
+		display.Println(display.Green, "%s plays %s (score: %d, depth: %d)", seat.Engine.Name, info.Move, info.Score, info.Depth)
+	} else {
+		display.Println(display.Green, "%s plays %s", seat.Engine.Name, info.Move)
+	}
+	if resp.State.IsOver() {
+		printGameOver(resp)
+	}
+	return nil
+}
\ No newline at end of file
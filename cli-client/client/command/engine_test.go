@@ -0,0 +1,124 @@
+// FILE: lixenwraith/chess/internal/client/command/engine_test.go
+package command
+
+import (
+	"testing"
+
+	"chess/internal/client/clienttest"
+)
+
+func TestEngineCommand(t *testing.T) {
+	engine := clienttest.FakeEngine(t)
+	attached := func(e *testEnv) {
+		e.newGame()
+		e.mustRun("engine attach -movetime 20 w " + engine + " -moves e2e4,g1f3")
+	}
+
+	runCases(t, []commandCase{
+		{
+			name:  "attach moves at once",
+			setup: func(e *testEnv) { e.newGame() },
+			line:  "engine attach -movetime 20 -option Hash=16 w " + engine + " -moves d2d4",
+			check: func(e *testEnv) {
+				if moves := e.moves(e.gameID); len(moves) != 1 || moves[0] != "d2d4" {
+					e.t.Fatalf("moves = %v", moves)
+				}
+			},
+		},
+		{
+			name: "plays against the server computer",
+			setup: func(e *testEnv) {
+				e.newComputerGame()
+				e.mustRun("engine attach -movetime 20 w " + engine + " -moves e2e4,g1f3")
+				e.fake.QueueComputerMoves("e7e5", "b8c6")
+			},
+			line: "engine play -max 3",
+			check: func(e *testEnv) {
+				want := []string{"e2e4", "e7e5", "g1f3", "b8c6"}
+				moves := e.moves(e.gameID)
+				if len(moves) != len(want) {
+					e.t.Fatalf("moves = %v, want %v", moves, want)
+				}
+				for i := range want {
+					if moves[i] != want[i] {
+						e.t.Fatalf("moves = %v, want %v", moves, want)
+					}
+				}
+			},
+		},
+		{
+			name:  "play stops at a human turn",
+			setup: attached,
+			line:  "engine play",
+			check: func(e *testEnv) {
+				if moves := e.moves(e.gameID); len(moves) != 1 {
+					e.t.Fatalf("moves = %v, want only the engine's first move", moves)
+				}
+			},
+		},
+		{
+			name: "undo takes back the engine's reply",
+			setup: func(e *testEnv) {
+				attached(e)
+				e.mustRun("move e7e5")
+				if err := engineTurn(e.session); err != nil {
+					e.t.Fatal(err)
+				}
+			},
+			line: "undo",
+			check: func(e *testEnv) {
+				if moves := e.moves(e.gameID); len(moves) != 1 || moves[0] != "e2e4" {
+					e.t.Fatalf("moves = %v, want only the engine's first move", moves)
+				}
+			},
+		},
+		{
+			name:  "status",
+			setup: attached,
+			line:  "U",
+		},
+		{
+			name:  "detach",
+			setup: attached,
+			line:  "engine detach",
+			check: func(e *testEnv) {
+				if e.session.GetEngineSeat() != nil {
+					e.t.Fatal("engine still attached")
+				}
+			},
+		},
+		{
+			name:    "detach without engine",
+			line:    "engine detach",
+			wantErr: "no engine attached",
+		},
+		{
+			name:    "computer side",
+			setup:   func(e *testEnv) { e.newComputerGame() },
+			line:    "engine attach b " + engine,
+			wantErr: "server computer",
+		},
+		{
+			name:    "missing binary",
+			setup:   func(e *testEnv) { e.newGame() },
+			line:    "engine attach w ./no-such-engine",
+			wantErr: "no-such-engine",
+		},
+		{
+			name:    "no current game",
+			line:    "engine attach w " + engine,
+			wantErr: "no current game",
+		},
+		{
+			name:    "play without engine",
+			setup:   func(e *testEnv) { e.newGame() },
+			line:    "engine play",
+			wantErr: "no engine attached",
+		},
+		{
+			name:    "unknown subcommand",
+			line:    "engine start",
+			wantErr: "usage",
+		},
+	})
+}
\ No newline at end of file
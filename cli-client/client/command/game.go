@@ -10,7 +10,9 @@ import (
 	"time"
 
 	"chess/internal/client/api"
+	"chess/internal/client/clock"
 	"chess/internal/client/display"
+	"chess/internal/client/rules"
 	"chess/internal/client/session"
 )
 
@@ -23,6 +25,22 @@ func (r *Registry) registerGameCommands() {
 		Handler:     newGameHandler,
 	})
 
+	r.Register(&Command{
+		Name:        "edit",
+		ShortName:   "E",
+		Description: "Set up a custom position and start a game from it",
+		Usage:       "edit [start | <fen>]",
+		Handler:     editHandler,
+	})
+
+	r.Register(&Command{
+		Name:        "import",
+		ShortName:   "I",
+		Description: "Start a game from a FEN, reporting every problem in it",
+		Usage:       "import [<fen>]",
+		Handler:     importHandler,
+	})
+
 	r.Register(&Command{
 		Name:        "join",
 		ShortName:   "j",
@@ -31,11 +49,19 @@ func (r *Registry) registerGameCommands() {
 		Handler:     joinGameHandler,
 	})
 
+	r.Register(&Command{
+		Name:        "games",
+		ShortName:   "g",
+		Description: "List, search and resume games",
+		Usage:       "games [list] [-mine] [-ongoing|-finished] [-computer|-human] [-page N] [-size N] | games resume [n|id-prefix]",
+		Handler:     gamesHandler,
+	})
+
 	r.Register(&Command{
 		Name:        "move",
 		ShortName:   "m",
 		Description: "Make a move",
-		Usage:       "move <uci-move>",
+		Usage:       "move <uci-move> [-y]",
 		Handler:     moveHandler,
 	})
 
@@ -50,8 +76,8 @@ func (r *Registry) registerGameCommands() {
 	r.Register(&Command{
 		Name:        "undo",
 		ShortName:   "u",
-		Description: "Undo moves",
-		Usage:       "undo [count]",
+		Description: "Take back moves",
+		Usage:       "undo [count | to <ply> | all] [-y]",
 		Handler:     undoHandler,
 	})
 
@@ -71,6 +97,14 @@ func (r *Registry) registerGameCommands() {
 		Handler:     gameStateHandler,
 	})
 
+	r.Register(&Command{
+		Name:        "pgn",
+		ShortName:   "P",
+		Description: "Write the game in PGN, with clock times",
+		Usage:       pgnUsage,
+		Handler:     pgnHandler,
+	})
+
 	r.Register(&Command{
 		Name:        "delete",
 		ShortName:   "d",
@@ -90,111 +124,109 @@ func (r *Registry) registerGameCommands() {
 
 func newGameHandler(s *session.Session, args []string) error {
 	scanner := bufio.NewScanner(os.Stdin)
-	c := s.Client
 
 	display.Println(display.Cyan, "\nCreating new game...")
 
-	// White player
-	display.Print(display.Yellow, "White player type (h/c) [h]: ")
-	scanner.Scan()
-	whiteType := strings.ToLower(strings.TrimSpace(scanner.Text()))
-	if whiteType == "" {
-		whiteType = "h"
+	white, err := promptPlayer(scanner, "White")
+	if err != nil {
+		return err
+	}
+	black, err := promptPlayer(scanner, "Black")
+	if err != nil {
+		return err
 	}
 
-	white := api.PlayerConfig{Type: 1}
-	if whiteType == "c" {
-		white.Type = 2
-
-		display.Print(display.Yellow, "Computer level (0-20) [10]: ")
-		scanner.Scan()
-		levelStr := strings.TrimSpace(scanner.Text())
-		if levelStr == "" {
-			white.Level = 10
-		} else {
-			level, _ := strconv.Atoi(levelStr)
-			white.Level = level
+	// Starting position
+	display.Print(display.Yellow, "Starting position (FEN) [default]: ")
+	scanner.Scan()
+	fen := strings.TrimSpace(scanner.Text())
+	if fen != "" {
+		if _, err := rules.CheckFEN(fen); err != nil {
+			return fenError(err)
 		}
+	}
 
-		display.Print(display.Yellow, "Search time (100-10000ms) [1000]: ")
-		scanner.Scan()
-		timeStr := strings.TrimSpace(scanner.Text())
-		if timeStr == "" {
-			white.SearchTime = 1000
-		} else {
-			searchTime, _ := strconv.Atoi(timeStr)
-			white.SearchTime = searchTime
-		}
+	tc, err := promptTimeControl(scanner)
+	if err != nil {
+		return err
 	}
 
-	// Black player (same pattern)
-	display.Print(display.Yellow, "Black player type (h/c) [h]: ")
+	return startGame(s, &api.CreateGameRequest{
+		White: white,
+		Black: black,
+		FEN:   fen,
+	}, tc)
+}
+
+// promptPlayer asks for one side's player type and computer settings
+func promptPlayer(scanner *bufio.Scanner, side string) (api.PlayerConfig, error) {
+	display.Print(display.Yellow, "%s player type (h/c) [h]: ", side)
 	scanner.Scan()
-	blackType := strings.ToLower(strings.TrimSpace(scanner.Text()))
-	if blackType == "" {
-		blackType = "h"
+	input := strings.TrimSpace(scanner.Text())
+	if input == "" {
+		input = "h"
+	}
+	playerType, err := api.ParsePlayerType(input)
+	if err != nil {
+		return api.PlayerConfig{}, err
 	}
 
-	black := api.PlayerConfig{Type: 1}
-	if blackType == "c" {
-		black.Type = 2
+	player := api.PlayerConfig{Type: playerType}
+	if playerType == api.Computer {
 
 		display.Print(display.Yellow, "Computer level (0-20) [10]: ")
 		scanner.Scan()
 		levelStr := strings.TrimSpace(scanner.Text())
 		if levelStr == "" {
-			black.Level = 10
+			player.Level = 10
 		} else {
 			level, _ := strconv.Atoi(levelStr)
-			black.Level = level
+			player.Level = level
 		}
 
 		display.Print(display.Yellow, "Search time (100-10000ms) [1000]: ")
 		scanner.Scan()
 		timeStr := strings.TrimSpace(scanner.Text())
 		if timeStr == "" {
-			black.SearchTime = 1000
+			player.SearchTime = 1000
 		} else {
 			searchTime, _ := strconv.Atoi(timeStr)
-			black.SearchTime = searchTime
+			player.SearchTime = searchTime
 		}
 	}
+	return player, nil
+}
 
-	// Starting position
-	display.Print(display.Yellow, "Starting position (FEN) [default]: ")
-	scanner.Scan()
-	fen := strings.TrimSpace(scanner.Text())
-
-	req := &api.CreateGameRequest{
-		White: white,
-		Black: black,
-		FEN:   fen,
-	}
-
-	resp, err := c.CreateGame(req)
+// startGame creates the game and makes it current, with a clock when tc
+// is set
+func startGame(s *session.Session, req *api.CreateGameRequest, tc *clock.TimeControl) error {
+	resp, err := s.GetBackend().CreateGame(req)
 	if err != nil {
 		return err
 	}
 
-	s.CurrentGame = resp.GameID
-	s.LastMoveCount = len(resp.Moves)
-	s.CurrentGameState = resp
+	s.SetCurrentGame(resp.GameID)
+	s.UpdateGame(resp)
 
 	// Determine player color if authenticated
-	if s.CurrentUser != "" {
-		if resp.Players.White.ID == s.CurrentUser {
-			s.PlayerColor = "w"
-		} else if resp.Players.Black.ID == s.CurrentUser {
-			s.PlayerColor = "b"
+	if user := s.GetCurrentUser(); user != "" {
+		if resp.Players.White.ID == user {
+			s.SetPlayerColor(api.White)
+		} else if resp.Players.Black.ID == user {
+			s.SetPlayerColor(api.Black)
 		}
 	}
+	s.RecordGame(resp)
 
 	display.Println(display.Green, "Game created: %s", resp.GameID)
 	display.Println(display.Cyan, "Current game set to: %s", resp.GameID)
+	if tc != nil {
+		startClock(s, resp, *tc)
+	}
 
-	// If white is computer, inform user to trigger move
-	if white.Type == 2 {
-		display.Println(display.Magenta, "\nWhite is computer. Use 'computer' or 'c' to trigger first move.")
+	// If the side to move is computer, inform user to trigger move
+	if resp.Players.Player(resp.Turn).Type == api.Computer {
+		display.Println(display.Magenta, "\n%s is computer. Use 'computer' or 'c' to trigger first move.", resp.Turn)
 	}
 
 	return nil
@@ -206,7 +238,7 @@ func joinGameHandler(s *session.Session, args []string) error {
 	}
 
 	gameID := args[0]
-	c := s.GetClient().(*api.Client)
+	c := s.GetBackend()
 
 	// Verify game exists
 	resp, err := c.GetGame(gameID)
@@ -221,13 +253,14 @@ func joinGameHandler(s *session.Session, args []string) error {
 	// Determine player color if authenticated
 	if s.GetCurrentUser() != "" {
 		if resp.Players.White.ID == s.GetCurrentUser() {
-			s.SetPlayerColor("w")
+			s.SetPlayerColor(api.White)
 		} else if resp.Players.Black.ID == s.GetCurrentUser() {
-			s.SetPlayerColor("b")
+			s.SetPlayerColor(api.Black)
 		} else {
-			s.SetPlayerColor("")
+			s.SetPlayerColor(api.NoColor)
 		}
 	}
+	s.RecordGame(resp)
 
 	fmt.Printf("%sJoined game: %s%s\n", display.Green, gameID, display.Reset)
 	fmt.Printf("Turn: %s | State: %s | Moves: %d\n", resp.Turn, resp.State, len(resp.Moves))
@@ -236,50 +269,49 @@ func joinGameHandler(s *session.Session, args []string) error {
 }
 
 func moveHandler(s *session.Session, args []string) error {
-	if len(args) < 1 {
-		return fmt.Errorf("usage: move <uci-move>")
+	confirmed := false
+	var rest []string
+	for _, arg := range args {
+		if arg == "-y" {
+			confirmed = true
+		} else {
+			rest = append(rest, arg)
+		}
+	}
+	if len(rest) < 1 {
+		return fmt.Errorf("usage: move <uci-move> [-y]")
 	}
 
-	gameID := s.CurrentGame
+	gameID := s.GetCurrentGame()
 	if gameID == "" {
 		return fmt.Errorf("no current game, use 'new' or 'join <gameId>'")
 	}
 
-	move := args[0]
-	c := s.Client
+	if err := checkFlag(s, gameID); err != nil {
+		return err
+	}
+
+	move := rest[0]
+	if !confirmed && !confirmRepetition(s, gameID, move) {
+		display.Println(display.Yellow, "Move cancelled")
+		return nil
+	}
+	c := s.GetBackend()
 
 	resp, err := c.MakeMove(gameID, move)
 	if err != nil {
 		return err
 	}
 
-	s.LastMoveCount = len(resp.Moves)
-	s.CurrentGameState = resp
+	s.UpdateGame(resp)
 	display.Println(display.Green, "Move accepted")
 
 	// Check if game ended
-	switch resp.State {
-	case "checkmate":
-		winner := "Black"
-		if resp.Turn == "b" { // Turn switches after move, so if black's turn after checkmate, white won
-			winner = "White"
-		}
-		display.Println(display.Green, "\nCHECKMATE! %s wins!", winner)
-	case "stalemate":
-		display.Println(display.Yellow, "\nSTALEMATE! Game drawn.")
-	case "draw":
-		display.Println(display.Yellow, "\nDRAW! Game drawn.")
-	case "ongoing":
+	if resp.State.IsOver() {
+		printGameOver(resp)
+	} else if resp.State == api.StateOngoing {
 		// Check if computer needs to play
-		currentTurn := resp.Turn
-		var computerPlayer *api.PlayerInfo
-		if currentTurn == "w" && resp.Players.White.Type == 2 {
-			computerPlayer = &resp.Players.White
-		} else if currentTurn == "b" && resp.Players.Black.Type == 2 {
-			computerPlayer = &resp.Players.Black
-		}
-
-		if computerPlayer != nil {
+		if resp.Players.Player(resp.Turn).Type == api.Computer {
 			display.Println(display.Magenta, "\nComputer's turn. Use 'computer' or 'c' to trigger move.")
 		}
 	}
@@ -287,29 +319,41 @@ func moveHandler(s *session.Session, args []string) error {
 	return nil
 }
 
+// printGameOver announces a finished game
+func printGameOver(game *api.GameResponse) {
+	color := display.Yellow
+	if game.State.Winner() != "" {
+		color = display.Green
+	}
+	display.Println(color, "\n%s", game.Reason())
+}
+
 func computerMoveHandler(s *session.Session, args []string) error {
-	gameID := s.CurrentGame
+	gameID := s.GetCurrentGame()
 	if gameID == "" {
 		return fmt.Errorf("no current game, use 'new' or 'join <gameId>'")
 	}
 
-	c := s.Client
+	if err := checkFlag(s, gameID); err != nil {
+		return err
+	}
+
+	c := s.GetBackend()
 
 	resp, err := c.MakeMove(gameID, "cccc")
 	if err != nil {
 		return err
 	}
 
-	if resp.State == "pending" {
+	if resp.State == api.StatePending {
 		display.Println(display.Magenta, "Computer is thinking...")
 
 		// Poll for completion
 		for i := 0; i < 50; i++ {
 			time.Sleep(200 * time.Millisecond)
 			resp2, err := c.GetGame(gameID)
-			if err == nil && resp2.State != "pending" {
-				s.LastMoveCount = len(resp2.Moves)
-				s.CurrentGameState = resp2
+			if err == nil && resp2.State != api.StatePending {
+				s.UpdateGame(resp2)
 				if resp2.LastMove != nil {
 					display.Print(display.Magenta, "Computer played: %s", resp2.LastMove.Move)
 					if resp2.LastMove.Depth > 0 {
@@ -319,17 +363,8 @@ func computerMoveHandler(s *session.Session, args []string) error {
 				}
 
 				// Check if game ended after computer move
-				switch resp2.State {
-				case "checkmate":
-					winner := "Black"
-					if resp2.Turn == "b" {
-						winner = "White"
-					}
-					display.Println(display.Green, "\nCHECKMATE! %s wins!", winner)
-				case "stalemate":
-					display.Println(display.Yellow, "\nSTALEMATE! Game drawn.")
-				case "draw":
-					display.Println(display.Yellow, "\nDRAW! Game drawn.")
+				if resp2.State.IsOver() {
+					printGameOver(resp2)
 				}
 
 				return nil
@@ -338,36 +373,11 @@ func computerMoveHandler(s *session.Session, args []string) error {
 		return fmt.Errorf("timeout waiting for computer move")
 	}
 
-	s.LastMoveCount = len(resp.Moves)
-	s.CurrentGameState = resp
+	s.UpdateGame(resp)
 	display.Println(display.Green, "Move triggered")
-	return nil
-}
-
-func undoHandler(s *session.Session, args []string) error {
-	gameID := s.GetCurrentGame()
-	if gameID == "" {
-		return fmt.Errorf("no current game, use 'new' or 'join <gameId>'")
-	}
-
-	count := 1
-	if len(args) > 0 {
-		var err error
-		count, err = strconv.Atoi(args[0])
-		if err != nil {
-			return fmt.Errorf("invalid count: %s", args[0])
-		}
+	if resp.State.IsOver() {
+		printGameOver(resp)
 	}
-
-	c := s.GetClient().(*api.Client)
-	resp, err := c.UndoMoves(gameID, count)
-	if err != nil {
-		return err
-	}
-
-	s.SetLastMoveCount(len(resp.Moves))
-	s.SetGameState(resp)
-	display.Println(display.Green, "Undid %d move(s)", count)
 	return nil
 }
 
@@ -377,7 +387,7 @@ func showBoardHandler(s *session.Session, args []string) error {
 		return fmt.Errorf("no current game, use 'new' or 'join <gameId>'")
 	}
 
-	c := s.GetClient().(*api.Client)
+	c := s.GetBackend()
 
 	// Get full game state
 	game, err := c.GetGame(gameID)
@@ -401,7 +411,12 @@ func showBoardHandler(s *session.Session, args []string) error {
 	// Display game info
 	fmt.Printf("\nFEN: %s\n", game.FEN)
 	fmt.Printf("Turn: %s | State: %s | Moves: %d\n",
-		display.ColorForTurn(game.Turn), game.State, len(game.Moves))
+		display.ColorForTurn(string(game.Turn)), game.State, len(game.Moves))
+	if opening, ok := openingOf(s, game); ok {
+		fmt.Printf("Opening: %s\n", opening)
+	}
+	printDrawCounters(s, game)
+	printClock(s, gameID)
 
 	// Display move history
 	if len(game.Moves) > 0 {
@@ -421,17 +436,17 @@ func showBoardHandler(s *session.Session, args []string) error {
 
 	// Display last move info
 	if game.LastMove != nil {
-		color := "White"
-		if game.LastMove.PlayerColor == "b" {
-			color = "Black"
-		}
-		fmt.Printf("Last move: %s by %s", game.LastMove.Move, color)
+		fmt.Printf("Last move: %s by %s", game.LastMove.Move, game.LastMove.PlayerColor)
 		if game.LastMove.Depth > 0 {
 			fmt.Printf(" (depth %d, score %d)", game.LastMove.Depth, game.LastMove.Score)
 		}
 		fmt.Println()
 	}
 
+	if game.State.IsOver() {
+		printGameOver(game)
+	}
+
 	return nil
 }
 
@@ -441,7 +456,7 @@ func gameStateHandler(s *session.Session, args []string) error {
 		return fmt.Errorf("no current game, use 'new' or 'join <gameId>'")
 	}
 
-	c := s.GetClient().(*api.Client)
+	c := s.GetBackend()
 	resp, err := c.GetGame(gameID)
 	if err != nil {
 		return err
@@ -466,12 +481,13 @@ func deleteGameHandler(s *session.Session, args []string) error {
 		return fmt.Errorf("specify game ID or set current game")
 	}
 
-	c := s.GetClient().(*api.Client)
+	c := s.GetBackend()
 	err := c.DeleteGame(gameID)
 	if err != nil {
 		return err
 	}
 
+	s.ForgetGame(gameID)
 	if gameID == s.GetCurrentGame() {
 		s.SetCurrentGame("")
 		s.SetLastMoveCount(0)
@@ -487,7 +503,7 @@ func pollHandler(s *session.Session, args []string) error {
 		return fmt.Errorf("no current game, use 'new' or 'join <gameId>'")
 	}
 
-	c := s.GetClient().(*api.Client)
+	c := s.GetBackend()
 	moveCount := s.GetLastMoveCount()
 
 	display.Println(display.Cyan, "Long-polling for updates (move count: %d)...", moveCount)
@@ -506,6 +522,9 @@ func pollHandler(s *session.Session, args []string) error {
 		if resp.LastMove != nil {
 			fmt.Printf("Last move: %s\n", resp.LastMove.Move)
 		}
+		if resp.State.IsOver() {
+			printGameOver(resp)
+		}
 	} else {
 		display.Println(display.Yellow, "No updates (timeout)")
 	}
@@ -0,0 +1,471 @@
+// FILE: lixenwraith/chess/internal/client/command/game_test.go
+package command
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/clienttest"
+)
+
+func TestNewCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name:  "human versus human",
+			line:  "new",
+			input: []string{"h", "h", ""},
+			check: func(e *testEnv) {
+				gameID := e.session.GetCurrentGame()
+				if gameID == "" || e.fake.GameCount() != 1 {
+					e.t.Fatalf("game not created, current %q, count %d", gameID, e.fake.GameCount())
+				}
+			},
+		},
+		{
+			name:  "computer plays black",
+			line:  "n",
+			input: []string{"h", "c", "3", "200", ""},
+			check: func(e *testEnv) {
+				game, _ := e.fake.Game(e.session.GetCurrentGame())
+				if game.Players.Black.Type != 2 || game.Players.Black.Level != 3 {
+					e.t.Fatalf("black = %+v, want computer level 3", game.Players.Black)
+				}
+			},
+		},
+		{
+			name:  "custom start position",
+			line:  "new",
+			input: []string{"h", "h", "4k3/8/8/8/8/8/8/4K2R w K - 0 1"},
+			check: func(e *testEnv) {
+				game, _ := e.fake.Game(e.session.GetCurrentGame())
+				if game.FEN != "4k3/8/8/8/8/8/8/4K2R w K - 0 1" {
+					e.t.Fatalf("fen = %q", game.FEN)
+				}
+			},
+		},
+		{
+			name:    "illegal start position",
+			line:    "new",
+			input:   []string{"h", "h", "4k3/8/8/8/8/8/8/K3K3 w - - 0 1"},
+			wantErr: "FEN rejected",
+			check: func(e *testEnv) {
+				if e.fake.GameCount() != 0 {
+					e.t.Fatal("rejected position reached the server")
+				}
+			},
+		},
+		{
+			name: "server error",
+			setup: func(e *testEnv) {
+				e.fake.FailNext("POST /api/v1/games", clienttest.Failure{Status: 500, Code: "INTERNAL", Message: "boom"})
+			},
+			line:    "new",
+			input:   []string{"h", "h", ""},
+			wantErr: "status 500",
+			check: func(e *testEnv) {
+				if e.session.GetCurrentGame() != "" {
+					e.t.Fatal("failed creation set a current game")
+				}
+			},
+		},
+	})
+}
+
+func TestCreateGameChecksFEN(t *testing.T) {
+	for _, backend := range []string{"server", "offline"} {
+		for _, fen := range []string{
+			"4k3/8/8/8/8/8/8/K3K3 w - - 0 1",  // two white kings
+			"8/8/8/8/8/8/8/8 w - a1 0 1",      // no kings, en passant on rank 1
+			"4k3/8/8/8/8/8/8/4K2r b - - 0 1",  // side not to move in check
+			"4k3/8/8/8/8/8/8/4K3 w - - x 1 2", // malformed
+		} {
+			t.Run(backend+" "+fen, func(t *testing.T) {
+				e := newTestEnv(t)
+				if backend == "offline" {
+					e.mustRun("offline on")
+				}
+				_, err := e.session.GetBackend().CreateGame(&api.CreateGameRequest{
+					White: api.PlayerConfig{Type: api.Human},
+					Black: api.PlayerConfig{Type: api.Human},
+					FEN:   fen,
+				})
+				var apiErr *api.APIError
+				if !errors.As(err, &apiErr) || apiErr.Status != 400 || apiErr.Response == nil || apiErr.Response.Code != "INVALID_FEN" {
+					t.Fatalf("err = %v, want a 400 INVALID_FEN error", err)
+				}
+			})
+		}
+	}
+}
+
+func TestJoinCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name: "existing game",
+			setup: func(e *testEnv) {
+				e.newGame()
+				e.mustRun("move e2e4")
+				e.session.SetCurrentGame("")
+			},
+			line: "join {game}",
+			check: func(e *testEnv) {
+				if e.session.GetCurrentGame() != e.gameID || e.session.GetLastMoveCount() != 1 {
+					e.t.Fatalf("current %q with %d moves, want %q with 1", e.session.GetCurrentGame(), e.session.GetLastMoveCount(), e.gameID)
+				}
+			},
+		},
+		{
+			name:    "missing id",
+			line:    "join",
+			wantErr: "usage",
+		},
+		{
+			name:    "unknown game",
+			line:    "j no-such-game",
+			wantErr: "status 404",
+		},
+	})
+}
+
+func TestMoveCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name:  "legal move",
+			setup: func(e *testEnv) { e.newGame() },
+			line:  "move e2e4",
+			check: func(e *testEnv) {
+				if moves := e.moves(e.session.GetCurrentGame()); len(moves) != 1 || moves[0] != "e2e4" {
+					e.t.Fatalf("moves = %v", moves)
+				}
+				if e.session.GetLastMoveCount() != 1 {
+					e.t.Fatalf("last move count = %d", e.session.GetLastMoveCount())
+				}
+			},
+		},
+		{
+			name:  "repetition warning declined",
+			setup: func(e *testEnv) { e.newGame(); e.playMoves(shuffle[:6]...) },
+			line:  "move f3g1",
+			input: []string{"n"},
+			check: func(e *testEnv) {
+				if moves := e.moves(e.gameID); len(moves) != 6 {
+					e.t.Fatalf("moves = %v, want the repeating move held back", moves)
+				}
+			},
+		},
+		{
+			name:  "repetition warning confirmed",
+			setup: func(e *testEnv) { e.newGame(); e.playMoves(shuffle[:6]...) },
+			line:  "move f3g1",
+			input: []string{"y"},
+			check: func(e *testEnv) {
+				if moves := e.moves(e.gameID); len(moves) != 7 {
+					e.t.Fatalf("moves = %v, want the repeating move played", moves)
+				}
+			},
+		},
+		{
+			name:    "opponent's piece",
+			setup:   func(e *testEnv) { e.newGame() },
+			line:    "m e7e5",
+			wantErr: "status 400",
+		},
+		{
+			name:    "no current game",
+			line:    "move e2e4",
+			wantErr: "no current game",
+		},
+		{
+			name:    "missing move",
+			setup:   func(e *testEnv) { e.newGame() },
+			line:    "move",
+			wantErr: "usage",
+		},
+		{
+			name:    "computer to move",
+			setup:   func(e *testEnv) { e.newComputerGame(); e.mustRun("move e2e4") },
+			line:    "move e7e5",
+			wantErr: "status 400",
+		},
+	})
+}
+
+func TestComputerCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name: "immediate reply",
+			setup: func(e *testEnv) {
+				e.newComputerGame()
+				e.mustRun("move e2e4")
+				e.fake.QueueComputerMoves("e7e5")
+			},
+			line: "computer",
+			check: func(e *testEnv) {
+				if moves := e.moves(e.session.GetCurrentGame()); len(moves) != 2 || moves[1] != "e7e5" {
+					e.t.Fatalf("moves = %v", moves)
+				}
+			},
+		},
+		{
+			name: "pending reply is polled",
+			setup: func(e *testEnv) {
+				e.newComputerGame()
+				e.mustRun("move e2e4")
+				e.fake.QueueComputerMoves("c7c5")
+				e.fake.SetComputerDelay(300 * time.Millisecond)
+			},
+			line: "c",
+			check: func(e *testEnv) {
+				if e.session.GetLastMoveCount() != 2 {
+					e.t.Fatalf("last move count = %d, want 2", e.session.GetLastMoveCount())
+				}
+			},
+		},
+		{
+			name:    "human to move",
+			setup:   func(e *testEnv) { e.newGame() },
+			line:    "computer",
+			wantErr: "status 400",
+		},
+		{
+			name:    "no current game",
+			line:    "computer",
+			wantErr: "no current game",
+		},
+	})
+}
+
+func TestUndoCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name:  "one move",
+			setup: func(e *testEnv) { e.newGame(); e.mustRun("move e2e4"); e.mustRun("move e7e5") },
+			line:  "undo 1",
+			check: func(e *testEnv) {
+				if moves := e.moves(e.session.GetCurrentGame()); len(moves) != 1 {
+					e.t.Fatalf("moves = %v, want 1", moves)
+				}
+			},
+		},
+		{
+			name: "own move with engine reply",
+			setup: func(e *testEnv) {
+				e.newComputerGame()
+				e.mustRun("move e2e4")
+				e.fake.QueueComputerMoves("e7e5")
+				e.mustRun("computer")
+			},
+			line: "undo",
+			check: func(e *testEnv) {
+				if moves := e.moves(e.session.GetCurrentGame()); len(moves) != 0 {
+					e.t.Fatalf("moves = %v, want none", moves)
+				}
+			},
+		},
+		{
+			name:  "to ply",
+			setup: func(e *testEnv) { e.newGame(); e.playMoves("e2e4", "e7e5", "g1f3") },
+			line:  "undo to 1",
+			check: func(e *testEnv) {
+				if moves := e.moves(e.session.GetCurrentGame()); len(moves) != 1 {
+					e.t.Fatalf("moves = %v, want 1", moves)
+				}
+			},
+		},
+		{
+			name:  "long take-back declined",
+			setup: func(e *testEnv) { e.newGame(); e.playMoves("e2e4", "e7e5", "g1f3", "b8c6", "f1b5") },
+			line:  "undo all",
+			input: []string{"n"},
+			check: func(e *testEnv) {
+				if moves := e.moves(e.session.GetCurrentGame()); len(moves) != 5 {
+					e.t.Fatalf("moves = %v, want all 5 kept", moves)
+				}
+			},
+		},
+		{
+			name:  "long take-back confirmed",
+			setup: func(e *testEnv) { e.newGame(); e.playMoves("e2e4", "e7e5", "g1f3", "b8c6", "f1b5") },
+			line:  "undo all -y",
+			check: func(e *testEnv) {
+				if moves := e.moves(e.session.GetCurrentGame()); len(moves) != 0 {
+					e.t.Fatalf("moves = %v, want none", moves)
+				}
+			},
+		},
+		{
+			name: "custom start with black to move",
+			setup: func(e *testEnv) {
+				e.mustRun("new", "h", "h", "4k3/8/8/8/8/8/8/4K2R b K - 0 5")
+				e.gameID = e.session.GetCurrentGame()
+				e.playMoves("e8d8", "e1g1", "d8c8")
+			},
+			line: "undo 2",
+			check: func(e *testEnv) {
+				if moves := e.moves(e.gameID); len(moves) != 1 {
+					e.t.Fatalf("moves = %v, want 1", moves)
+				}
+			},
+		},
+		{
+			name:    "invalid count",
+			setup:   func(e *testEnv) { e.newGame(); e.mustRun("move e2e4") },
+			line:    "undo x",
+			wantErr: "invalid count",
+		},
+		{
+			name:    "nothing to undo",
+			setup:   func(e *testEnv) { e.newGame() },
+			line:    "undo",
+			wantErr: "no moves to undo",
+		},
+		{
+			name:    "no current game",
+			line:    "u",
+			wantErr: "no current game",
+		},
+	})
+}
+
+func TestFormatPlies(t *testing.T) {
+	moves := []string{"e2e4", "e7e5", "g1f3", "b8c6"}
+	cases := []struct {
+		ply    int
+		first  api.Color
+		number int
+		want   string
+	}{
+		{0, api.White, 1, "1.e2e4 e7e5 2.g1f3 b8c6"},
+		{1, api.White, 1, "1...e7e5 2.g1f3 b8c6"},
+		{0, api.Black, 5, "5...e2e4 6.e7e5 g1f3 7.b8c6"},
+		{2, api.Black, 5, "6...g1f3 7.b8c6"},
+	}
+	for _, tc := range cases {
+		if got := formatPlies(moves, tc.ply, tc.first, tc.number); got != tc.want {
+			t.Errorf("formatPlies(%d, %s, %d) = %q, want %q", tc.ply, tc.first, tc.number, got, tc.want)
+		}
+	}
+}
+
+func TestShowCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name:  "board and history",
+			setup: func(e *testEnv) { e.newGame(); e.mustRun("move e2e4") },
+			line:  "show",
+			check: func(e *testEnv) {
+				if state := e.session.GetGameState(); state == nil || len(state.Moves) != 1 {
+					e.t.Fatalf("game state not refreshed: %+v", state)
+				}
+			},
+		},
+		{
+			name: "board request fails",
+			setup: func(e *testEnv) {
+				e.newGame()
+				e.fake.FailNext("GET /api/v1/games/{id}/board", clienttest.Failure{Status: 503, Code: "UNAVAILABLE", Message: "down"})
+			},
+			line:    "h",
+			wantErr: "status 503",
+		},
+		{
+			name:    "no current game",
+			line:    "show",
+			wantErr: "no current game",
+		},
+	})
+}
+
+func TestStateCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name:  "raw json",
+			setup: func(e *testEnv) { e.newGame(); e.mustRun("move d2d4") },
+			line:  "state",
+			check: func(e *testEnv) {
+				if e.session.GetLastMoveCount() != 1 {
+					e.t.Fatalf("last move count = %d", e.session.GetLastMoveCount())
+				}
+			},
+		},
+		{
+			name: "deleted game",
+			setup: func(e *testEnv) {
+				gameID := e.newGame()
+				if err := e.client.DeleteGame(gameID); err != nil {
+					e.t.Fatal(err)
+				}
+			},
+			line:    "s",
+			wantErr: "status 404",
+		},
+		{
+			name:    "no current game",
+			line:    "state",
+			wantErr: "no current game",
+		},
+	})
+}
+
+func TestDeleteCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name:  "current game",
+			setup: func(e *testEnv) { e.newGame() },
+			line:  "delete",
+			check: func(e *testEnv) {
+				if e.fake.GameCount() != 0 || e.session.GetCurrentGame() != "" {
+					e.t.Fatalf("game count %d, current %q", e.fake.GameCount(), e.session.GetCurrentGame())
+				}
+			},
+		},
+		{
+			name:    "unknown game",
+			line:    "d no-such-game",
+			wantErr: "status 404",
+		},
+		{
+			name:    "nothing to delete",
+			line:    "delete",
+			wantErr: "specify game ID",
+		},
+	})
+}
+
+func TestPollCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name: "move arrives",
+			setup: func(e *testEnv) {
+				gameID := e.newGame()
+				go func() {
+					time.Sleep(50 * time.Millisecond)
+					e.client.MakeMove(gameID, "e2e4")
+				}()
+			},
+			line: "poll",
+			check: func(e *testEnv) {
+				if e.session.GetLastMoveCount() != 1 {
+					e.t.Fatalf("last move count = %d, want 1", e.session.GetLastMoveCount())
+				}
+			},
+		},
+		{
+			name:  "timeout without change",
+			setup: func(e *testEnv) { e.newGame() },
+			line:  "p",
+			check: func(e *testEnv) {
+				if e.session.GetLastMoveCount() != 0 {
+					e.t.Fatalf("last move count = %d, want 0", e.session.GetLastMoveCount())
+				}
+			},
+		},
+		{
+			name:    "no current game",
+			line:    "poll",
+			wantErr: "no current game",
+		},
+	})
+}
\ No newline at end of file
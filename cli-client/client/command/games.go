@@ -0,0 +1,242 @@
+// FILE: lixenwraith/chess/internal/client/command/games.go
+package command
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/display"
+	"chess/internal/client/session"
+)
+
+// searchLimit is how many games 'games resume' looks through for a prefix
+const searchLimit = 200
+
+func gamesHandler(s *session.Session, args []string) error {
+	if len(args) > 0 && args[0] == "resume" {
+		return resumeGame(s, args[1:])
+	}
+	if len(args) > 0 && args[0] == "list" {
+		args = args[1:]
+	}
+
+	q := api.ListGamesQuery{}
+	var ongoing, finished, computer, human bool
+	var page int
+	fs := flag.NewFlagSet("games", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.BoolVar(&q.Mine, "mine", false, "only games you play in")
+	fs.BoolVar(&ongoing, "ongoing", false, "only unfinished games")
+	fs.BoolVar(&finished, "finished", false, "only finished games")
+	fs.BoolVar(&computer, "computer", false, "only games against the computer")
+	fs.BoolVar(&human, "human", false, "only games with a human opponent")
+	fs.IntVar(&page, "page", 1, "page number")
+	fs.IntVar(&q.Limit, "size", 10, "games per page")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%v\nusage: games [list] [-mine] [-ongoing|-finished] [-computer|-human] [-page N] [-size N]", err)
+	}
+	if ongoing && finished || computer && human {
+		return fmt.Errorf("-ongoing/-finished and -computer/-human are mutually exclusive")
+	}
+	if page < 1 || q.Limit < 1 {
+		return fmt.Errorf("page and size must be positive")
+	}
+	switch {
+	case ongoing:
+		q.State = "ongoing"
+	case finished:
+		q.State = "finished"
+	}
+	switch {
+	case computer:
+		q.Opponent = api.Computer
+	case human:
+		q.Opponent = api.Human
+	}
+	q.Offset = (page - 1) * q.Limit
+
+	if q.Mine && !s.IsOffline() && s.GetAuthToken() == "" {
+		return fmt.Errorf("-mine needs a login, use 'login' first")
+	}
+
+	list, fromLog, err := listGames(s, &q)
+	if err != nil {
+		return err
+	}
+	if fromLog {
+		display.Println(display.Yellow, "Server cannot list games, showing games from this client's history")
+	}
+	if len(list.Games) == 0 {
+		display.Println(display.Yellow, "No games found")
+		return nil
+	}
+
+	printGameList(list, s.GetCurrentGame())
+	// Servers that omit the page size are taken to have used the requested one
+	limit := list.Limit
+	if limit <= 0 {
+		limit = q.Limit
+	}
+	if limit <= 0 {
+		limit = api.DefaultListLimit
+	}
+	pages := (list.Total + limit - 1) / limit
+	fmt.Printf("Page %d of %d (%d games)\n", page, pages, list.Total)
+
+	// Interactive picker
+	first, last := list.Offset+1, list.Offset+len(list.Games)
+	display.Print(display.Yellow, "Resume game [%d-%d, enter to skip]: ", first, last)
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Scan()
+	choice := strings.TrimSpace(scanner.Text())
+	if choice == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(choice)
+	if err != nil || n < first || n > last {
+		return fmt.Errorf("invalid choice: %s", choice)
+	}
+	return joinGameHandler(s, []string{list.Games[n-first].GameID})
+}
+
+// resumeGame joins a game by list position on the first page or by ID prefix
+func resumeGame(s *session.Session, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: games resume <n|id-prefix>")
+	}
+
+	q := api.ListGamesQuery{Limit: searchLimit}
+	list, _, err := listGames(s, &q)
+	if err != nil {
+		return err
+	}
+
+	if n, err := strconv.Atoi(args[0]); err == nil && len(args[0]) < 4 {
+		if n < 1 || n > len(list.Games) {
+			return fmt.Errorf("no game number %d", n)
+		}
+		return joinGameHandler(s, []string{list.Games[n-1].GameID})
+	}
+
+	var matches []api.GameSummary
+	for _, g := range list.Games {
+		if strings.HasPrefix(g.GameID, strings.ToLower(args[0])) {
+			matches = append(matches, g)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("no game matches %q", args[0])
+	case 1:
+		return joinGameHandler(s, []string{matches[0].GameID})
+	}
+	printGameList(&api.GameListResponse{Games: matches, Total: len(matches)}, s.GetCurrentGame())
+	return fmt.Errorf("%d games match %q, use a longer prefix", len(matches), args[0])
+}
+
+// listGames asks the backend for a listing and falls back to the session's
+// game log when the backend cannot list games
+func listGames(s *session.Session, q *api.ListGamesQuery) (*api.GameListResponse, bool, error) {
+	if lister, ok := s.GetBackend().(session.Lister); ok {
+		list, err := lister.ListGames(q)
+		if err == nil {
+			return list, false, nil
+		}
+		if !listingUnsupported(err) {
+			return nil, false, err
+		}
+	}
+
+	records := s.GameLog()
+	games := make([]api.GameSummary, len(records))
+	for i, rec := range records {
+		games[i] = api.GameSummary{
+			GameID:    rec.GameID,
+			State:     rec.State,
+			MoveCount: rec.Moves,
+			Players: api.PlayersResponse{
+				White: api.PlayerInfo{Type: rec.White},
+				Black: api.PlayerInfo{Type: rec.Black},
+			},
+			CreatedAt: rec.Created,
+			UpdatedAt: rec.Updated,
+		}
+		// Mark the side played from this client so the Mine filter applies
+		switch rec.Color {
+		case api.White:
+			games[i].Players.White.ID = "me"
+		case api.Black:
+			games[i].Players.Black.ID = "me"
+		}
+	}
+	return api.PageGames(games, q, "me"), true, nil
+}
+
+// listingUnsupported reports errors from servers without a listing endpoint
+// or that cannot be reached at all
+func listingUnsupported(err error) bool {
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+	switch apiErr.Status {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
+
+func printGameList(list *api.GameListResponse, current string) {
+	fmt.Printf("\n  %3s  %-8s  %-13s  %-13s  %5s  %-11s  %s\n", "#", "Game", "White", "Black", "Moves", "State", "Updated")
+	for i, g := range list.Games {
+		marker := " "
+		if g.GameID == current {
+			marker = "*"
+		}
+		id := g.GameID
+		if len(id) > 8 {
+			id = id[:8]
+		}
+		stateColor := display.Green
+		if g.State.IsOver() {
+			stateColor = display.Yellow
+		}
+		fmt.Printf("%s %3d  %-8s  %-13s  %-13s  %5d  %s  %s\n", marker, list.Offset+i+1, id,
+			playerLabel(g.Players.White), playerLabel(g.Players.Black), g.MoveCount,
+			display.C(stateColor, fmt.Sprintf("%-11s", g.State)), formatAge(g.UpdatedAt))
+	}
+	fmt.Println()
+}
+
+func playerLabel(p api.PlayerInfo) string {
+	if p.Type == api.Computer && p.Level > 0 {
+		return fmt.Sprintf("computer L%d", p.Level)
+	}
+	return p.Type.String()
+}
+
+// formatAge renders how long ago t was, coarsely
+func formatAge(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	}
+	return t.Local().Format("2006-01-02")
+}
@@ -0,0 +1,114 @@
+// FILE: lixenwraith/chess/internal/client/command/games_test.go
+package command
+
+import (
+	"testing"
+
+	"chess/internal/client/api"
+	"chess/internal/client/clienttest"
+)
+
+// noLimitLister answers listings without a page size, as older servers do
+type noLimitLister struct {
+	*api.Client
+}
+
+func (l noLimitLister) ListGames(q *api.ListGamesQuery) (*api.GameListResponse, error) {
+	list, err := l.Client.ListGames(q)
+	if list != nil {
+		list.Limit = 0
+	}
+	return list, err
+}
+
+func TestGamesCommand(t *testing.T) {
+	// created plays one game and leaves no current game
+	created := func(e *testEnv) {
+		e.newGame()
+		e.mustRun("move e2e4")
+		e.session.SetCurrentGame("")
+	}
+	resumed := func(e *testEnv) {
+		if e.session.GetCurrentGame() != e.gameID {
+			e.t.Fatalf("current game %q, want %q", e.session.GetCurrentGame(), e.gameID)
+		}
+	}
+
+	runCases(t, []commandCase{
+		{
+			name:  "pick from list",
+			setup: created,
+			line:  "games",
+			input: []string{"1"},
+			check: resumed,
+		},
+		{
+			name:  "filtered list skipped",
+			setup: created,
+			line:  "g list -ongoing -human -size 5",
+			input: []string{""},
+			check: func(e *testEnv) {
+				if e.session.GetCurrentGame() != "" {
+					e.t.Fatal("skipping the picker joined a game")
+				}
+			},
+		},
+		{
+			name:  "resume by prefix",
+			setup: created,
+			line:  "games resume {game}",
+			check: resumed,
+		},
+		{
+			name: "log fallback",
+			setup: func(e *testEnv) {
+				created(e)
+				e.fake.FailNext("GET /api/v1/games", clienttest.Failure{Status: 404, Code: "NOT_FOUND", Message: "no listing"})
+			},
+			line:  "games",
+			input: []string{"1"},
+			check: resumed,
+		},
+		{
+			name: "page size omitted",
+			setup: func(e *testEnv) {
+				created(e)
+				e.session.SetBackend(noLimitLister{e.client})
+			},
+			line:  "games",
+			input: []string{"1"},
+			check: resumed,
+		},
+		{
+			name: "server error",
+			setup: func(e *testEnv) {
+				e.fake.FailNext("GET /api/v1/games", clienttest.Failure{Status: 500, Code: "INTERNAL", Message: "boom"})
+			},
+			line:    "games",
+			wantErr: "status 500",
+		},
+		{
+			name:    "invalid choice",
+			setup:   created,
+			line:    "games",
+			input:   []string{"7"},
+			wantErr: "invalid choice",
+		},
+		{
+			name:    "conflicting filters",
+			line:    "games -ongoing -finished",
+			wantErr: "mutually exclusive",
+		},
+		{
+			name:    "mine without login",
+			line:    "games -mine",
+			wantErr: "needs a login",
+		},
+		{
+			name:    "resume unknown prefix",
+			setup:   created,
+			line:    "games resume zzzz",
+			wantErr: "no game matches",
+		},
+	})
+}
\ No newline at end of file
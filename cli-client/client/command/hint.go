@@ -0,0 +1,106 @@
+// FILE: lixenwraith/chess/internal/client/command/hint.go
+package command
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/display"
+	"chess/internal/client/engine"
+	"chess/internal/client/rules"
+	"chess/internal/client/session"
+)
+
+const hintUsage = "hint [-time MS] [-level N] | hint level [N]"
+
+func hintHandler(s *session.Session, args []string) error {
+	if len(args) > 0 && args[0] == "level" {
+		return hintLevel(s, args[1:])
+	}
+
+	var searchMs, level int
+	fs := flag.NewFlagSet("hint", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.IntVar(&searchMs, "time", int(engine.DefaultSearchTime.Milliseconds()), "search time in milliseconds")
+	fs.IntVar(&level, "level", s.GetHintLevel(), "engine level 0-20 for this hint")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return fmt.Errorf("usage: %s", hintUsage)
+	}
+	searchTime := time.Duration(searchMs) * time.Millisecond
+	if searchTime < api.MinSearchTime || searchTime > api.MaxSearchTime {
+		return fmt.Errorf("search time must be %d-%d ms", api.MinSearchTime.Milliseconds(), api.MaxSearchTime.Milliseconds())
+	}
+	if level < 0 || level > engine.MaxLevel {
+		return fmt.Errorf("level must be 0-%d", engine.MaxLevel)
+	}
+
+	gameID := s.GetCurrentGame()
+	if gameID == "" {
+		return fmt.Errorf("no current game, use 'new' or 'join <gameId>'")
+	}
+	c := s.GetBackend()
+	game, err := c.GetGame(gameID)
+	if err != nil {
+		return err
+	}
+	if game.State != api.StateOngoing {
+		return fmt.Errorf("no hint, game is %s", game.State)
+	}
+	if game.Players.Player(game.Turn).Type != api.Human {
+		return fmt.Errorf("%s is played by the computer, hints are for the human side", game.Turn)
+	}
+	if color := s.GetPlayerColor(); color.Valid() && color != game.Turn {
+		return fmt.Errorf("not your turn, %s to move", game.Turn)
+	}
+	if seat := s.GetEngineSeat(); seat != nil && seat.GameID == gameID && seat.Color == game.Turn {
+		return fmt.Errorf("%s is played by %s", game.Turn, seat.Engine.Name)
+	}
+
+	pos, err := rules.ParseFEN(game.FEN)
+	if err != nil {
+		return fmt.Errorf("cannot read game position: %v", err)
+	}
+
+	// The search runs in a scratch game, the real one is left untouched
+	display.Println(display.Magenta, "Looking for a move (level %d)...", level)
+	info, err := api.SearchPosition(c, game.FEN, level, searchTime)
+	if err != nil {
+		return err
+	}
+	m, err := pos.ParseMove(info.Move)
+	if err != nil {
+		return fmt.Errorf("engine suggested an illegal move %s", info.Move)
+	}
+
+	fmt.Println()
+	display.RenderBoardArrow(pos.ASCII(), rules.SquareName(m.From), rules.SquareName(m.To))
+	fmt.Println()
+
+	score := info.Score
+	if pos.Turn == rules.Black {
+		score = -score
+	}
+	fmt.Printf("Hint: %s (%s)  score %s\n", display.C(display.Green, pos.SAN(m)), m,
+		display.C(scoreColor(score), formatScore(score)))
+	display.Println(display.Cyan, "Play it with 'move %s'", m)
+	return nil
+}
+
+// hintLevel shows or sets the engine level hints are searched at
+func hintLevel(s *session.Session, args []string) error {
+	if len(args) == 0 {
+		fmt.Printf("Hint level: %d\n", s.GetHintLevel())
+		return nil
+	}
+	level, err := strconv.Atoi(args[0])
+	if err != nil || level < 0 || level > engine.MaxLevel || len(args) > 1 {
+		return fmt.Errorf("usage: hint level <0-%d>", engine.MaxLevel)
+	}
+	s.SetHintLevel(level)
+	display.Println(display.Green, "Hint level set to %d", level)
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,57 @@
+// FILE: lixenwraith/chess/internal/client/command/hint_test.go
+package command
+
+import "testing"
+
+func TestHintCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name:  "suggests without moving",
+			setup: func(e *testEnv) { e.newGame(); e.mustRun("move e2e4") },
+			line:  "hint -time 100",
+			check: func(e *testEnv) {
+				if moves := e.moves(e.gameID); len(moves) != 1 {
+					e.t.Fatalf("moves = %v, the hint changed the game", moves)
+				}
+				if e.fake.GameCount() != 1 {
+					e.t.Fatalf("%d games on the server, scratch game left behind", e.fake.GameCount())
+				}
+			},
+		},
+		{
+			name: "set level",
+			line: "H level 4",
+			check: func(e *testEnv) {
+				if e.session.GetHintLevel() != 4 {
+					e.t.Fatalf("hint level = %d", e.session.GetHintLevel())
+				}
+			},
+		},
+		{
+			name: "show level",
+			line: "hint level",
+		},
+		{
+			name:    "level out of range",
+			line:    "hint level 21",
+			wantErr: "usage",
+		},
+		{
+			name:    "computer to move",
+			setup:   func(e *testEnv) { e.newComputerGame(); e.mustRun("move e2e4") },
+			line:    "hint -time 100",
+			wantErr: "played by the computer",
+		},
+		{
+			name:    "search time out of range",
+			setup:   func(e *testEnv) { e.newGame() },
+			line:    "hint -time 5",
+			wantErr: "search time must be",
+		},
+		{
+			name:    "no current game",
+			line:    "hint",
+			wantErr: "no current game",
+		},
+	})
+}
\ No newline at end of file
@@ -0,0 +1,94 @@
+// FILE: lixenwraith/chess/internal/client/command/history.go
+package command
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"chess/internal/client/api"
+	"chess/internal/client/display"
+	"chess/internal/client/session"
+)
+
+func (r *Registry) registerHistoryCommands() {
+	r.Register(&Command{
+		Name:        "history",
+		ShortName:   "y",
+		Description: "List API calls, export as curl or HAR",
+		Usage:       "history [curl <n> | har <file> | clear] [--token]",
+		Handler:     historyHandler,
+	})
+}
+
+func historyHandler(s *session.Session, args []string) error {
+	h, ok := s.GetBackend().(session.Historian)
+	if !ok {
+		return fmt.Errorf("current backend keeps no request history")
+	}
+
+	// Tokens are redacted unless explicitly requested
+	includeToken := false
+	var rest []string
+	for _, arg := range args {
+		if arg == "--token" {
+			includeToken = true
+		} else {
+			rest = append(rest, arg)
+		}
+	}
+
+	history := h.History()
+	if len(rest) == 0 {
+		if len(history) == 0 {
+			display.Println(display.Yellow, "No API calls recorded")
+			return nil
+		}
+		display.Println(display.Cyan, "API History:")
+		for i, ex := range history {
+			color := display.Green
+			if ex.Err != nil || ex.Status >= 400 {
+				color = display.Red
+			}
+			fmt.Printf("  %3d  %s\n", i+1, display.C(color, ex.Summary()))
+		}
+		return nil
+	}
+
+	switch rest[0] {
+	case "curl":
+		if len(rest) < 2 {
+			return fmt.Errorf("usage: history curl <n> [--token]")
+		}
+		n, err := strconv.Atoi(rest[1])
+		if err != nil || n < 1 || n > len(history) {
+			return fmt.Errorf("invalid entry: %s (have %d)", rest[1], len(history))
+		}
+		fmt.Println(history[n-1].Curl(includeToken))
+		if !includeToken && history[n-1].RequestHeader.Get("Authorization") != "" {
+			display.Println(display.Yellow, "Token redacted; export %s or use --token", api.TokenVariable[1:])
+		}
+	case "har":
+		if len(rest) < 2 {
+			return fmt.Errorf("usage: history har <file> [--token]")
+		}
+		f, err := os.Create(rest[1])
+		if err != nil {
+			return err
+		}
+		if err := api.WriteHAR(f, history, includeToken); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		display.Println(display.Green, "Exported %d request(s) to: %s", len(history), rest[1])
+	case "clear":
+		h.ClearHistory()
+		display.Println(display.Green, "History cleared")
+	default:
+		return fmt.Errorf("usage: history [curl <n> | har <file> | clear] [--token]")
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+// FILE: lixenwraith/chess/internal/client/command/history_test.go
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"chess/internal/client/api"
+)
+
+func TestHistoryCommand(t *testing.T) {
+	harPath := filepath.Join(t.TempDir(), "session.har")
+	runCases(t, []commandCase{
+		{
+			name:  "list",
+			setup: func(e *testEnv) { e.newGame(); e.mustRun("move e2e4") },
+			line:  "history",
+			check: func(e *testEnv) {
+				if n := len(e.client.History()); n < 2 {
+					e.t.Fatalf("history has %d exchanges, want at least 2", n)
+				}
+			},
+		},
+		{
+			name:  "curl entry",
+			setup: func(e *testEnv) { e.newGame() },
+			line:  "y curl 1",
+			check: func(e *testEnv) {
+				got := e.client.History()[0].Curl(false)
+				if !strings.HasPrefix(got, "curl -X POST '"+e.url+"/api/v1/games'") || !strings.Contains(got, `"type":1`) {
+					e.t.Fatalf("curl = %s", got)
+				}
+			},
+		},
+		{
+			name:  "har export",
+			setup: func(e *testEnv) { e.newGame(); e.mustRun("move e2e4") },
+			line:  "history har " + harPath,
+			check: func(e *testEnv) {
+				data, err := os.ReadFile(harPath)
+				if err != nil {
+					e.t.Fatal(err)
+				}
+				if !json.Valid(data) {
+					e.t.Fatalf("har export is not JSON: %.80s", data)
+				}
+			},
+		},
+		{
+			name:  "clear",
+			setup: func(e *testEnv) { e.newGame() },
+			line:  "history clear",
+			check: func(e *testEnv) {
+				if n := len(e.client.History()); n != 0 {
+					e.t.Fatalf("history has %d exchanges after clear", n)
+				}
+			},
+		},
+		{
+			name:    "curl out of range",
+			setup:   func(e *testEnv) { e.newGame() },
+			line:    "history curl 99",
+			wantErr: "invalid entry",
+		},
+		{
+			name:    "har without file",
+			line:    "history har",
+			wantErr: "usage",
+		},
+		{
+			name:    "offline backend",
+			setup:   func(e *testEnv) { e.mustRun("offline on") },
+			line:    "history",
+			wantErr: "no request history",
+		},
+	})
+}
+
+// captureStdout returns what f prints to standard output
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		out <- string(data)
+	}()
+	old := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = old }()
+	f()
+	w.Close()
+	return <-out
+}
+
+func TestHistoryRedaction(t *testing.T) {
+	const password = "correct-horse-battery"
+	e := newTestEnv(t)
+	registerUser(e, "alice", password)
+	auth, err := e.client.Login("alice", password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.client.SetToken(auth.Token)
+	e.newGame()
+	secrets := []string{password, auth.Token}
+
+	harPath := filepath.Join(t.TempDir(), "session.har")
+	har := func(args string) string {
+		t.Helper()
+		e.mustRun("history har " + harPath + args)
+		data, err := os.ReadFile(harPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	redacted := har("")
+	var curls strings.Builder
+	for i := range e.client.History() {
+		curls.WriteString(captureStdout(t, func() { e.mustRun(fmt.Sprintf("history curl %d", i+1)) }))
+	}
+	for _, secret := range secrets {
+		if strings.Contains(redacted, secret) {
+			t.Errorf("har export contains %q", secret)
+		}
+		if strings.Contains(curls.String(), secret) {
+			t.Errorf("curl output contains %q", secret)
+		}
+	}
+	if !strings.Contains(curls.String(), api.TokenVariable) {
+		t.Errorf("curl output does not stand the token in with %s", api.TokenVariable)
+	}
+
+	full := har(" --token")
+	for _, secret := range secrets {
+		if !strings.Contains(full, secret) {
+			t.Errorf("har export with --token lacks %q", secret)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,126 @@
+// FILE: lixenwraith/chess/internal/client/command/loadtest.go
+package command
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"chess/internal/client/display"
+	"chess/internal/client/loadtest"
+	"chess/internal/client/session"
+)
+
+func (r *Registry) registerLoadTestCommands() {
+	r.Register(&Command{
+		Name:        "loadtest",
+		ShortName:   "L",
+		Description: "Drive many simultaneous games against the server",
+		Usage:       "loadtest [-players N] [-duration D] [-rate R] [-computer F] [-register] [-level L] [-searchtime MS]",
+		Handler:     loadTestHandler,
+	})
+}
+
+func loadTestHandler(s *session.Session, args []string) error {
+	if s.IsOffline() {
+		return fmt.Errorf("load tests need a server backend, use 'offline off' first")
+	}
+
+	cfg := loadtest.Config{BaseURL: s.GetAPIBaseURL()}
+	fs := flag.NewFlagSet("loadtest", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.IntVar(&cfg.Players, "players", 10, "concurrent virtual players")
+	fs.DurationVar(&cfg.Duration, "duration", 30*time.Second, "test duration")
+	fs.Float64Var(&cfg.Rate, "rate", 0, "total moves per second, 0 for unthrottled")
+	fs.Float64Var(&cfg.Computer, "computer", 0.2, "fraction of games against the engine")
+	fs.BoolVar(&cfg.Register, "register", false, "register and log in a user per player")
+	fs.IntVar(&cfg.Level, "level", 1, "engine level for computer games")
+	fs.IntVar(&cfg.SearchTime, "searchtime", 100, "engine search time in milliseconds")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%v\nusage: loadtest [-players N] [-duration D] [-rate R] [-computer F] [-register] [-level L] [-searchtime MS]", err)
+	}
+	if cfg.Players < 1 || cfg.Duration <= 0 || cfg.Computer < 0 || cfg.Computer > 1 {
+		return fmt.Errorf("players must be positive, duration positive and computer within 0-1")
+	}
+	if !(cfg.Rate >= 0 && cfg.Rate <= loadtest.MaxRate) {
+		return fmt.Errorf("rate must be within 0-%.0f moves per second", loadtest.MaxRate)
+	}
+
+	display.Println(display.Cyan, "Load test against %s", cfg.BaseURL)
+	fmt.Printf("  Players: %d | Duration: %s | Rate: %s | Computer games: %.0f%%\n",
+		cfg.Players, cfg.Duration, rateString(cfg.Rate), cfg.Computer*100)
+
+	report := loadtest.Run(context.Background(), cfg, 5*time.Second, func(p loadtest.Progress) {
+		display.Println(display.Blue, "  %5.0fs  %d games, %d moves", p.Elapsed.Seconds(), p.Games, p.Moves)
+	})
+
+	printLoadTestReport(report)
+	return nil
+}
+
+func rateString(rate float64) string {
+	if rate <= 0 {
+		return "unthrottled"
+	}
+	return fmt.Sprintf("%.1f moves/s", rate)
+}
+
+func printLoadTestReport(r *loadtest.Report) {
+	secs := r.Elapsed.Seconds()
+	moves := r.HumanMoves + r.EngineMoves
+
+	display.Println(display.Cyan, "\nLoad Test Results:")
+	fmt.Printf("  Elapsed:    %s\n", r.Elapsed.Round(time.Millisecond))
+	fmt.Printf("  Games:      %d created, %d finished\n", r.GamesCreated, r.GamesFinished)
+	fmt.Printf("  Moves:      %d (%d human, %d engine)\n", moves, r.HumanMoves, r.EngineMoves)
+	fmt.Printf("  Throughput: %.1f req/s, %.1f moves/s\n", float64(r.Requests())/secs, float64(moves)/secs)
+
+	errColor := display.Green
+	if r.Errors() > 0 {
+		errColor = display.Red
+	}
+	fmt.Printf("  Errors:     %s of %d requests\n", display.C(errColor, fmt.Sprint(r.Errors())), r.Requests())
+
+	if len(r.Routes) > 0 {
+		display.Println(display.Cyan, "\nLatency by Route:")
+		fmt.Printf("  %-38s %7s %9s %9s %9s\n", "Route", "Calls", "p50", "p95", "p99")
+		for _, rs := range r.Routes {
+			fmt.Printf("  %-38s %7d %9s %9s %9s\n", rs.Route, rs.Count,
+				formatLatency(rs.Percentile(50)), formatLatency(rs.Percentile(95)), formatLatency(rs.Percentile(99)))
+		}
+	}
+
+	if r.Errors() == 0 && len(r.Failures) == 0 {
+		return
+	}
+	display.Println(display.Cyan, "\nError Breakdown:")
+	for _, rs := range r.Routes {
+		httpErrors := 0
+		codes := make([]int, 0, len(rs.Statuses))
+		for code := range rs.Statuses {
+			if code >= 400 {
+				codes = append(codes, code)
+				httpErrors += rs.Statuses[code]
+			}
+		}
+		sort.Ints(codes)
+		for _, code := range codes {
+			display.Println(display.Red, "  %-38s %d × %d", rs.Route, code, rs.Statuses[code])
+		}
+		if transport := rs.Errors - httpErrors; transport > 0 {
+			display.Println(display.Red, "  %-38s transport × %d", rs.Route, transport)
+		}
+	}
+
+	messages := make([]string, 0, len(r.Failures))
+	for msg := range r.Failures {
+		messages = append(messages, msg)
+	}
+	sort.Strings(messages)
+	for _, msg := range messages {
+		display.Println(display.Yellow, "  %s × %d", msg, r.Failures[msg])
+	}
+}
@@ -0,0 +1,52 @@
+// FILE: lixenwraith/chess/internal/client/command/loadtest_test.go
+package command
+
+import "testing"
+
+func TestLoadTestCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name: "short run",
+			line: "loadtest -players 2 -duration 300ms -computer 0.5",
+		},
+		{
+			name: "throttled with registration",
+			line: "L -players 2 -duration 300ms -rate 20 -register",
+		},
+		{
+			name:    "unknown flag",
+			line:    "loadtest -threads 4",
+			wantErr: "usage",
+		},
+		{
+			name:    "computer fraction out of range",
+			line:    "loadtest -computer 2",
+			wantErr: "computer within 0-1",
+		},
+		{
+			name: "rate below one slot per run",
+			line: "loadtest -players 1 -duration 200ms -rate 0.0000000000001",
+		},
+		{
+			name:    "negative rate",
+			line:    "loadtest -rate -5",
+			wantErr: "rate must be within",
+		},
+		{
+			name:    "rate too high",
+			line:    "loadtest -rate 1e12",
+			wantErr: "rate must be within",
+		},
+		{
+			name:    "rate not a number",
+			line:    "loadtest -rate NaN",
+			wantErr: "rate must be within",
+		},
+		{
+			name:    "offline backend",
+			setup:   func(e *testEnv) { e.mustRun("offline on") },
+			line:    "loadtest",
+			wantErr: "server backend",
+		},
+	})
+}
\ No newline at end of file
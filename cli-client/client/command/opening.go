@@ -0,0 +1,75 @@
+// FILE: lixenwraith/chess/internal/client/command/opening.go
+package command
+
+import (
+	"fmt"
+
+	"chess/internal/client/api"
+	"chess/internal/client/display"
+	"chess/internal/client/eco"
+	"chess/internal/client/session"
+)
+
+func openingHandler(s *session.Session, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("usage: opening")
+	}
+	gameID := s.GetCurrentGame()
+	if gameID == "" {
+		return fmt.Errorf("no current game, use 'new' or 'join <gameId>'")
+	}
+	game, err := s.GetBackend().GetGame(gameID)
+	if err != nil {
+		return err
+	}
+	s.SetGameState(game)
+
+	history := s.Replay(game)
+	if history == nil {
+		return fmt.Errorf("cannot replay the game from its start position")
+	}
+	positions := history.Positions()
+
+	if opening, ply, ok := eco.Classify(positions); ok {
+		display.Print(display.Cyan, "Opening: ")
+		fmt.Print(opening)
+		if ply < history.Plies() {
+			m, _ := positions[ply].ParseMove(game.Moves[ply])
+			fmt.Printf(" (left the book with %s)", plyLabel(positions[0], ply, positions[ply].SAN(m)))
+		}
+		fmt.Println()
+	} else {
+		display.Println(display.Yellow, "Opening: not in the book")
+	}
+
+	if game.State.IsOver() || game.State == api.StatePending {
+		return nil
+	}
+	continuations := eco.Continuations(history.Position())
+	if len(continuations) == 0 {
+		fmt.Println("No book moves from this position")
+		return nil
+	}
+	display.Println(display.Cyan, "\nBook moves:")
+	for _, c := range continuations {
+		label := plyLabel(positions[0], history.Plies(), c.SAN)
+		name := c.Opening.String()
+		if !c.Named {
+			// A step on the way to a named line
+			name = fmt.Sprintf("%s (%d lines)", name, c.Lines)
+		}
+		fmt.Printf("  %-12s %s\n", label, name)
+	}
+	return nil
+}
+
+// openingOf names the opening of a game, false if it cannot be replayed or
+// never reached a book position
+func openingOf(s *session.Session, game *api.GameResponse) (eco.Opening, bool) {
+	history := s.Replay(game)
+	if history == nil {
+		return eco.Opening{}, false
+	}
+	opening, _, ok := eco.Classify(history.Positions())
+	return opening, ok
+}
\ No newline at end of file
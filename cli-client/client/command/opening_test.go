@@ -0,0 +1,59 @@
+// FILE: lixenwraith/chess/internal/client/command/opening_test.go
+package command
+
+import (
+	"testing"
+
+	"chess/internal/client/api"
+)
+
+func TestOpeningCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name:  "named line",
+			setup: func(e *testEnv) { e.newGame(); e.playMoves("e2e4", "e7e5", "g1f3", "b8c6", "f1b5") },
+			line:  "opening",
+		},
+		{
+			name:  "out of the book",
+			setup: func(e *testEnv) { e.newGame(); e.playMoves("a2a3", "h7h6", "h2h3", "a7a6") },
+			line:  "O",
+		},
+		{
+			name:  "start position",
+			setup: func(e *testEnv) { e.newGame() },
+			line:  "opening",
+		},
+		{
+			name: "unknown start position",
+			setup: func(e *testEnv) {
+				game, err := e.client.CreateGame(&api.CreateGameRequest{
+					White: api.PlayerConfig{Type: api.Human},
+					Black: api.PlayerConfig{Type: api.Human},
+					FEN:   "4k3/8/8/8/8/8/8/4K2R w K - 0 1",
+				})
+				if err != nil {
+					e.t.Fatal(err)
+				}
+				if _, err := e.client.MakeMove(game.GameID, "e1g1"); err != nil {
+					e.t.Fatal(err)
+				}
+				e.gameID = game.GameID
+				e.mustRun("join {game}")
+			},
+			line:    "opening",
+			wantErr: "cannot replay",
+		},
+		{
+			name:    "arguments",
+			setup:   func(e *testEnv) { e.newGame() },
+			line:    "opening e4",
+			wantErr: "usage",
+		},
+		{
+			name:    "no current game",
+			line:    "opening",
+			wantErr: "no current game",
+		},
+	})
+}
\ No newline at end of file
@@ -9,7 +9,9 @@ import (
 	"golang.org/x/term"
 )
 
-func readPassword(prompt string) (string, error) {
+// readPassword reads a password without echoing it; a variable so tests can
+// answer without a terminal
+var readPassword = func(prompt string) (string, error) {
 	fmt.Print(prompt)
 	bytePassword, err := term.ReadPassword(0) // 0 is stdin
 	fmt.Println()
@@ -12,7 +12,8 @@ import (
 	"chess/internal/client/display"
 )
 
-func readPassword(prompt string) (string, error) {
+// readPassword reads a password from the terminal page, where it is echoed
+var readPassword = func(prompt string) (string, error) {
 	display.Println(display.Red, "(warning: password visible in browser)")
 	display.Print(display.Yellow, prompt)
 
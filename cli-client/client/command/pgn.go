@@ -0,0 +1,107 @@
+// FILE: lixenwraith/chess/internal/client/command/pgn.go
+package command
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/clock"
+	"chess/internal/client/display"
+	"chess/internal/client/eco"
+	"chess/internal/client/pgn"
+	"chess/internal/client/rules"
+	"chess/internal/client/session"
+)
+
+const pgnUsage = "pgn [file]"
+
+func pgnHandler(s *session.Session, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("usage: %s", pgnUsage)
+	}
+	gameID := s.GetCurrentGame()
+	if gameID == "" {
+		return fmt.Errorf("no current game, use 'new' or 'join <gameId>'")
+	}
+	game, err := s.GetBackend().GetGame(gameID)
+	if err != nil {
+		return err
+	}
+	s.SetGameState(game)
+
+	history := s.Replay(game)
+	if history == nil {
+		return fmt.Errorf("cannot replay the game from its start position")
+	}
+	g := gamePGN(s, game, history.Positions())
+
+	if len(args) == 0 {
+		fmt.Println()
+		return g.Write(os.Stdout)
+	}
+	f, err := os.Create(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %v", args[0], err)
+	}
+	defer f.Close()
+	if err := g.Write(f); err != nil {
+		return fmt.Errorf("failed to write %s: %v", args[0], err)
+	}
+	display.Println(display.Green, "Game written to %s", args[0])
+	return nil
+}
+
+// gamePGN builds the score of a game replayed through positions, with its
+// opening, the clock's time control and a %clk comment on every move it
+// timed
+func gamePGN(s *session.Session, game *api.GameResponse, positions []*rules.Position) *pgn.Game {
+	start := positions[0]
+	g := pgn.NewGame()
+	g.SetTag("Event", "Casual game")
+	g.SetTag("Site", s.GetAPIBaseURL())
+	g.SetTag("Date", time.Now().Format("2006.01.02"))
+	g.SetTag("Round", "-")
+	g.SetTag("White", playerLabel(game.Players.White))
+	g.SetTag("Black", playerLabel(game.Players.Black))
+	g.SetTag("Result", game.State.Result())
+	if start.FEN() != rules.StartFEN {
+		g.StartFEN = start.FEN()
+	}
+	if opening, _, ok := eco.Classify(positions); ok {
+		g.SetTag("ECO", opening.ECO)
+		g.SetTag("Opening", opening.Name)
+	}
+
+	// Clock times cover the plies played since the clock started
+	var clockTimes []time.Duration
+	clockStart := 0
+	if gc := s.GetClock(game.GameID); gc != nil {
+		g.SetTag("TimeControl", gc.Control.PGN())
+		clockTimes, clockStart = gc.MoveTimes(), gc.StartPly
+		if flagged := gc.Flagged(time.Now()); flagged.Valid() {
+			g.SetTag("Result", flaggedResult(flagged))
+			g.SetTag("Termination", "time forfeit")
+		}
+	}
+
+	for i, uciMove := range game.Moves {
+		pos := positions[i]
+		m, _ := pos.ParseMove(uciMove)
+		move := pgn.Move{SAN: pos.SAN(m)}
+		if j := i - clockStart; j >= 0 && j < len(clockTimes) {
+			move.Comment = fmt.Sprintf("[%%clk %s]", clock.FormatPGN(clockTimes[j]))
+		}
+		g.Moves = append(g.Moves, move)
+	}
+	return g
+}
+
+// flaggedResult is the result of a game lost on time by flagged
+func flaggedResult(flagged api.Color) string {
+	if flagged == api.White {
+		return "0-1"
+	}
+	return "1-0"
+}
\ No newline at end of file
@@ -0,0 +1,65 @@
+// FILE: lixenwraith/chess/internal/client/command/pgn_test.go
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPGNCommand(t *testing.T) {
+	pgnPath := filepath.Join(t.TempDir(), "game.pgn")
+	contains := func(want ...string) func(e *testEnv) {
+		return func(e *testEnv) {
+			data, err := os.ReadFile(pgnPath)
+			if err != nil {
+				e.t.Fatal(err)
+			}
+			for _, w := range want {
+				if !strings.Contains(string(data), w) {
+					e.t.Fatalf("pgn has no %s:\n%s", w, data)
+				}
+			}
+		}
+	}
+
+	runCases(t, []commandCase{
+		{
+			name: "clock times",
+			setup: func(e *testEnv) {
+				e.newGame()
+				e.mustRun("clock start 5+3")
+				e.playMoves("e2e4", "e7e5")
+			},
+			line:  "pgn " + pgnPath,
+			check: contains(`[TimeControl "300+3"]`, "1. e4 { [%clk 0:05:0", "1... e5 { [%clk 0:05:0", `[Result "*"]`, `[ECO "C20"]`, `[Opening "King's Pawn Game"]`),
+		},
+		{
+			name: "custom start position",
+			setup: func(e *testEnv) {
+				e.mustRun("new", "h", "h", "4k3/8/8/8/8/8/8/4K2R w K - 0 1")
+				e.gameID = e.session.GetCurrentGame()
+				e.playMoves("e1g1")
+			},
+			line:  "pgn " + pgnPath,
+			check: contains(`[FEN "4k3/8/8/8/8/8/8/4K2R w K - 0 1"]`, "1. O-O"),
+		},
+		{
+			name:  "standard output",
+			setup: func(e *testEnv) { e.newGame(); e.playMoves("d2d4") },
+			line:  "P",
+		},
+		{
+			name:    "too many arguments",
+			setup:   func(e *testEnv) { e.newGame() },
+			line:    "pgn a b",
+			wantErr: "usage",
+		},
+		{
+			name:    "no current game",
+			line:    "pgn",
+			wantErr: "no current game",
+		},
+	})
+}
\ No newline at end of file
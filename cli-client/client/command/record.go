@@ -0,0 +1,56 @@
+// FILE: lixenwraith/chess/internal/client/command/record.go
+package command
+
+import (
+	"fmt"
+
+	"chess/internal/client/display"
+	"chess/internal/client/session"
+)
+
+func (r *Registry) registerRecordCommands() {
+	r.Register(&Command{
+		Name:        "record",
+		ShortName:   "w",
+		Description: "Record API traffic to a cassette file",
+		Usage:       "record start <file> | record stop | record",
+		Handler:     recordHandler,
+	})
+}
+
+func recordHandler(s *session.Session, args []string) error {
+	rec, ok := s.GetBackend().(session.Recorder)
+	if !ok {
+		return fmt.Errorf("current backend cannot record, use 'offline off' first")
+	}
+
+	if len(args) == 0 {
+		if path, ok := rec.IsRecording(); ok {
+			fmt.Printf("Recording to: %s\n", path)
+		} else {
+			fmt.Println("Not recording")
+		}
+		return nil
+	}
+
+	switch args[0] {
+	case "start":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: record start <file>")
+		}
+		if err := rec.StartRecording(args[1]); err != nil {
+			return err
+		}
+		display.Println(display.Cyan, "Recording API traffic to: %s", args[1])
+		display.Println(display.Yellow, "Authorization headers, passwords and tokens are redacted")
+	case "stop":
+		path, count, err := rec.StopRecording()
+		if err != nil {
+			return err
+		}
+		display.Println(display.Green, "Saved %d interaction(s) to: %s", count, path)
+	default:
+		return fmt.Errorf("usage: record start <file> | record stop")
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// FILE: lixenwraith/chess/internal/client/command/record_test.go
+package command
+
+import (
+	"path/filepath"
+	"testing"
+
+	"chess/internal/client/api"
+)
+
+func TestRecordCommand(t *testing.T) {
+	cassettePath := filepath.Join(t.TempDir(), "session.json")
+	runCases(t, []commandCase{
+		{
+			name: "start, play and stop",
+			setup: func(e *testEnv) {
+				e.mustRun("record start " + cassettePath)
+				e.newGame()
+				e.mustRun("move e2e4")
+			},
+			line: "record stop",
+			check: func(e *testEnv) {
+				cassette, err := api.LoadCassette(cassettePath)
+				if err != nil {
+					e.t.Fatal(err)
+				}
+				if len(cassette.Interactions) < 2 {
+					e.t.Fatalf("recorded %d interactions, want at least 2", len(cassette.Interactions))
+				}
+			},
+		},
+		{
+			name: "status while idle",
+			line: "w",
+		},
+		{
+			name:    "start without file",
+			line:    "record start",
+			wantErr: "usage",
+		},
+		{
+			name:    "stop without recording",
+			line:    "record stop",
+			wantErr: "not recording",
+		},
+		{
+			name:    "already recording",
+			setup:   func(e *testEnv) { e.mustRun("record start " + filepath.Join(t.TempDir(), "a.json")) },
+			line:    "record start " + filepath.Join(t.TempDir(), "b.json"),
+			wantErr: "already recording",
+		},
+		{
+			name:    "offline backend",
+			setup:   func(e *testEnv) { e.mustRun("offline on") },
+			line:    "record",
+			wantErr: "cannot record",
+		},
+		{
+			name:    "unknown subcommand",
+			line:    "record pause",
+			wantErr: "usage",
+		},
+	})
+}
\ No newline at end of file
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"strings"
 
-	"chess/internal/client/api"
 	"chess/internal/client/display"
 	"chess/internal/client/session"
 )
@@ -33,8 +32,17 @@ func NewRegistry(s *session.Session) *Registry {
 
 	// Register all commands
 	r.registerGameCommands()
+	r.registerEngineCommands()
+	r.registerClockCommands()
+	r.registerDrawCommands()
+	r.registerAnalysisCommands()
 	r.registerAuthCommands()
 	r.registerDebugCommands()
+	r.registerRecordCommands()
+	r.registerHistoryCommands()
+	r.registerStatsCommands()
+	r.registerLoadTestCommands()
+	r.registerConformanceCommands()
 
 	// Help command
 	r.Register(&Command{
@@ -80,13 +88,26 @@ func (r *Registry) Execute(input string) {
 		return
 	}
 
-	// Set verbose mode in client if session supports it
-	if cl, ok := r.session.GetClient().(*api.Client); ok {
-		cl.SetVerbose(r.session.IsVerbose())
+	// Set verbose mode in backend if it supports tracing
+	if t, ok := r.session.GetBackend().(session.Tracer); ok {
+		t.SetVerbose(r.session.IsVerbose())
 	}
 
 	if err := cmd.Handler(r.session, args); err != nil {
 		display.Println(display.Red, "Error: %s", err.Error())
+		return
+	}
+
+	// Report a flag fall as soon as it is noticed
+	if gameID := r.session.GetCurrentGame(); gameID != "" && checkFlag(r.session, gameID) != nil {
+		return
+	}
+
+	// An attached engine answers as soon as the game reaches its turn
+	if cmd.Name != "engine" {
+		if err := engineTurn(r.session); err != nil {
+			display.Println(display.Red, "Engine error: %s", err.Error())
+		}
 	}
 }
 
@@ -119,12 +140,27 @@ func (r *Registry) helpHandler(s *session.Session, args []string) error {
 
 	gameCommands := []cmdInfo{
 		{"new", "n", ""},
+		{"edit", "E", ""},
+		{"import", "I", ""},
 		{"join", "j", ""},
+		{"games", "g", ""},
 		{"move", "m", ""},
 		{"computer", "c", ""},
+		{"engine", "U", ""},
+		{"clock", "T", ""},
+		{"analyze", "a", ""},
+		{"review", "v", ""},
+		{"hint", "H", ""},
+		{"opening", "O", ""},
 		{"undo", "u", ""},
+		{"resign", "", ""},
+		{"offer-draw", "", ""},
+		{"accept", "", ""},
+		{"decline", "", ""},
+		{"claim", "", ""},
 		{"show", "h", ""},
 		{"state", "s", ""},
+		{"pgn", "P", ""},
 		{"delete", "d", ""},
 		{"poll", "p", ""},
 	}
@@ -141,6 +177,12 @@ func (r *Registry) helpHandler(s *session.Session, args []string) error {
 		{"health", ".", ""},
 		{"url", "/", ""},
 		{"raw", ":", ""},
+		{"offline", "f", ""},
+		{"record", "w", ""},
+		{"history", "y", ""},
+		{"stats", "t", ""},
+		{"loadtest", "L", ""},
+		{"conformance", "K", ""},
 		{"help", "?", ""},
 		{"exit", "x", ""},
 	}
@@ -149,7 +191,7 @@ func (r *Registry) helpHandler(s *session.Session, args []string) error {
 		display.Println(display.Yellow, "%s:", title)
 		for _, info := range cmds {
 			if cmd, exists := r.commands[info.name]; exists {
-				shortPart := ""
+				shortPart := "    "
 				if info.shortName != "" {
 					shortPart = fmt.Sprintf("[%s%s%s] ", display.Cyan, info.shortName, display.Reset)
 				}
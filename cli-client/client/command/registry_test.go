@@ -0,0 +1,54 @@
+// FILE: lixenwraith/chess/internal/client/command/registry_test.go
+package command
+
+import (
+	"testing"
+)
+
+func TestHelpCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name: "all commands",
+			line: "help",
+		},
+		{
+			name: "one command by short name",
+			line: "? m",
+		},
+		{
+			name:    "unknown command",
+			line:    "help bogus",
+			wantErr: "unknown command",
+		},
+	})
+}
+
+func TestExitCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name: "goodbye",
+			line: "exit",
+		},
+	})
+}
+
+// TestEveryCommandTested guards against commands registered without a test
+// case in this package
+func TestEveryCommandTested(t *testing.T) {
+	tested := map[string]bool{
+		"new": true, "join": true, "move": true, "computer": true, "undo": true,
+		"show": true, "state": true, "delete": true, "poll": true,
+		"register": true, "login": true, "logout": true, "whoami": true, "user": true,
+		"health": true, "url": true, "raw": true, "offline": true, "help": true, "exit": true,
+		"record": true, "history": true, "stats": true, "loadtest": true, "conformance": true,
+		"games": true, "edit": true, "import": true, "engine": true, "analyze": true, "review": true,
+		"hint": true, "clock": true, "opening": true, "pgn": true,
+		"resign": true, "offer-draw": true, "accept": true, "decline": true, "claim": true,
+	}
+	e := newTestEnv(t)
+	for name, cmd := range e.registry.commands {
+		if name == cmd.Name && !tested[name] {
+			t.Errorf("command %q has no test", name)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,316 @@
+// FILE: lixenwraith/chess/internal/client/command/review.go
+package command
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/display"
+	"chess/internal/client/engine"
+	"chess/internal/client/pgn"
+	"chess/internal/client/rules"
+	"chess/internal/client/session"
+)
+
+// Centipawn losses at which a move is flagged
+const (
+	inaccuracyLoss = 50
+	mistakeLoss    = 100
+	blunderLoss    = 300
+)
+
+const (
+	// lossCap bounds evaluations when computing losses, so mate scores do
+	// not dwarf everything else
+	lossCap = 1000
+	// graphRows is the number of rows above and below the axis, one pawn each
+	graphRows  = 5
+	graphWidth = 64
+)
+
+const reviewUsage = "review [-time MS] [-level N] [-fen start-fen] [-pgn file]"
+
+// reviewPly is the evaluation around one move of the game
+type reviewPly struct {
+	san   string
+	mover rules.Color
+	best  string // engine's choice in SAN, "" if not searched
+	loss  int    // centipawns lost by the mover
+}
+
+func reviewHandler(s *session.Session, args []string) error {
+	var searchMs, level int
+	var startFEN, pgnPath string
+	fs := flag.NewFlagSet("review", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.IntVar(&searchMs, "time", 300, "search time per position in milliseconds")
+	fs.IntVar(&level, "level", engine.MaxLevel, "engine level 0-20")
+	fs.StringVar(&startFEN, "fen", "", "start position of games not started from the standard one")
+	fs.StringVar(&pgnPath, "pgn", "", "write the annotated game to file")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%v\nusage: %s", err, reviewUsage)
+	}
+	if startFEN != "" {
+		// The FEN's other fields follow the flag as arguments of their own
+		startFEN = strings.Join(append([]string{startFEN}, fs.Args()...), " ")
+	}
+	searchTime := time.Duration(searchMs) * time.Millisecond
+	if searchTime < api.MinSearchTime || searchTime > api.MaxSearchTime {
+		return fmt.Errorf("search time must be %d-%d ms", api.MinSearchTime.Milliseconds(), api.MaxSearchTime.Milliseconds())
+	}
+
+	gameID := s.GetCurrentGame()
+	if gameID == "" {
+		return fmt.Errorf("no current game, use 'new' or 'join <gameId>'")
+	}
+	c := s.GetBackend()
+	game, err := c.GetGame(gameID)
+	if err != nil {
+		return err
+	}
+	if len(game.Moves) == 0 {
+		return fmt.Errorf("no moves to review")
+	}
+	if game.State == api.StatePending {
+		return fmt.Errorf("computer move in progress, wait for it before reviewing")
+	}
+
+	var history *rules.History
+	if startFEN != "" {
+		if _, err := rules.CheckFEN(startFEN); err != nil {
+			return fenError(err)
+		}
+		history = session.ReplayFrom(startFEN, game)
+	} else {
+		history = s.Replay(game)
+	}
+	if history == nil {
+		return fmt.Errorf("cannot replay the game from its start position, pass it with -fen")
+	}
+	positions := history.Positions()
+	start := positions[0]
+
+	// Evaluate every position from White's point of view
+	evals := make([]int, len(positions))
+	bests := make([]string, len(positions))
+	for i, pos := range positions {
+		display.Print(display.Cyan, "\rEvaluating position %d/%d", i+1, len(positions))
+		if evals[i], bests[i], err = evaluate(c, pos, level, searchTime); err != nil {
+			fmt.Println()
+			return fmt.Errorf("position %d: %w", i, err)
+		}
+	}
+	fmt.Print("\n\n")
+
+	plies := make([]reviewPly, len(game.Moves))
+	for i, uciMove := range game.Moves {
+		pos := positions[i]
+		m, _ := pos.ParseMove(uciMove)
+		plies[i] = reviewPly{san: pos.SAN(m), mover: pos.Turn, best: bests[i]}
+		if plies[i].san == plies[i].best {
+			continue
+		}
+		before, after := capEval(evals[i]), capEval(evals[i+1])
+		if pos.Turn == rules.White {
+			plies[i].loss = max(0, before-after)
+		} else {
+			plies[i].loss = max(0, after-before)
+		}
+	}
+
+	printEvalGraph(evals)
+	printReviewList(plies, start)
+
+	if pgnPath != "" {
+		g := reviewPGN(s, game, positions, plies, evals)
+		f, err := os.Create(pgnPath)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %v", pgnPath, err)
+		}
+		defer f.Close()
+		if err := g.Write(f); err != nil {
+			return fmt.Errorf("failed to write %s: %v", pgnPath, err)
+		}
+		display.Println(display.Green, "Annotated game written to %s", pgnPath)
+	}
+	return nil
+}
+
+// evaluate scores a position from White's point of view and returns the
+// engine's move in SAN; finished positions are scored without a search
+func evaluate(c api.GameClient, pos *rules.Position, level int, searchTime time.Duration) (int, string, error) {
+	switch pos.Status() {
+	case rules.Checkmate:
+		if pos.Turn == rules.White {
+			return -engine.MateScore, "", nil
+		}
+		return engine.MateScore, "", nil
+	case rules.Stalemate, rules.SeventyFiveMoves, rules.InsufficientMaterial:
+		return 0, "", nil
+	}
+
+	info, err := api.SearchPosition(c, pos.FEN(), level, searchTime)
+	if err != nil {
+		return 0, "", err
+	}
+	score := info.Score
+	if pos.Turn == rules.Black {
+		score = -score
+	}
+	best := info.Move
+	if m, err := pos.ParseMove(info.Move); err == nil {
+		best = pos.SAN(m)
+	}
+	return score, best, nil
+}
+
+func capEval(cp int) int {
+	return max(-lossCap, min(cp, lossCap))
+}
+
+// classify names the severity of a centipawn loss, "" for a good move
+func classify(loss int) (name, suffix string) {
+	switch {
+	case loss >= blunderLoss:
+		return "Blunder", "??"
+	case loss >= mistakeLoss:
+		return "Mistake", "?"
+	case loss >= inaccuracyLoss:
+		return "Inaccuracy", "?!"
+	}
+	return "", ""
+}
+
+// printEvalGraph draws evaluations as columns above and below the axis,
+// one row per pawn, sampling long games down to graphWidth columns
+func printEvalGraph(evals []int) {
+	cols := evals
+	if len(evals) > graphWidth {
+		cols = make([]int, graphWidth)
+		for i := range cols {
+			cols[i] = evals[i*len(evals)/graphWidth]
+		}
+	}
+
+	row := func(label string, color string, filled func(cp int) bool) {
+		var b strings.Builder
+		for _, cp := range cols {
+			if filled(cp) {
+				b.WriteByte('#')
+			} else {
+				b.WriteByte(' ')
+			}
+		}
+		fmt.Printf("%4s |%s\n", label, display.C(color, b.String()))
+	}
+
+	display.Println(display.Cyan, "Evaluation (White's point of view, pawns)")
+	for r := graphRows; r >= 1; r-- {
+		threshold := r*100 - 50
+		row(fmt.Sprintf("+%d", r), display.Green, func(cp int) bool { return cp >= threshold })
+	}
+	fmt.Printf("%4s +%s\n", "0", strings.Repeat("-", len(cols)))
+	for r := 1; r <= graphRows; r++ {
+		threshold := r*100 - 50
+		row(fmt.Sprintf("-%d", r), display.Red, func(cp int) bool { return cp <= -threshold })
+	}
+	last := fmt.Sprintf("ply %d", len(evals)-1)
+	gap := max(1, len(cols)-len("ply 0")-len(last))
+	fmt.Printf("%4s  ply 0%s%s\n\n", "", strings.Repeat(" ", gap), last)
+}
+
+// printReviewList lists flagged moves and per-side averages
+func printReviewList(plies []reviewPly, start *rules.Position) {
+	type sideStats struct {
+		moves, loss int
+		counts      map[string]int
+	}
+	stats := map[rules.Color]*sideStats{
+		rules.White: {counts: map[string]int{}},
+		rules.Black: {counts: map[string]int{}},
+	}
+
+	display.Println(display.Cyan, "Flagged moves")
+	flagged := false
+	for i, p := range plies {
+		st := stats[p.mover]
+		st.moves++
+		st.loss += min(p.loss, lossCap)
+		name, suffix := classify(p.loss)
+		if name == "" {
+			continue
+		}
+		st.counts[name]++
+		flagged = true
+
+		color := display.Yellow
+		if name == "Blunder" {
+			color = display.Red
+		}
+		fmt.Printf("  %-10s %s  %s  best was %s\n", display.C(color, name),
+			plyLabel(start, i, p.san+suffix), display.C(display.Blue, fmt.Sprintf("(-%.2f)", float64(p.loss)/100)), p.best)
+	}
+	if !flagged {
+		fmt.Println("  none")
+	}
+
+	fmt.Println()
+	for _, c := range []rules.Color{rules.White, rules.Black} {
+		st := stats[c]
+		if st.moves == 0 {
+			continue
+		}
+		fmt.Printf("%s: average loss %d cp, %d inaccuracies, %d mistakes, %d blunders\n", c,
+			st.loss/st.moves, st.counts["Inaccuracy"], st.counts["Mistake"], st.counts["Blunder"])
+	}
+}
+
+// plyLabel numbers a move the way it is written in a game score
+func plyLabel(start *rules.Position, ply int, san string) string {
+	abs := ply
+	if start.Turn == rules.Black {
+		abs++
+	}
+	number := start.Fullmove + abs/2
+	if abs%2 == 0 {
+		return fmt.Sprintf("%d. %s", number, san)
+	}
+	return fmt.Sprintf("%d... %s", number, san)
+}
+
+// reviewPGN builds the annotated game with an eval comment on every move
+func reviewPGN(s *session.Session, game *api.GameResponse, positions []*rules.Position, plies []reviewPly, evals []int) *pgn.Game {
+	g := gamePGN(s, game, positions)
+	g.SetTag("Annotator", "chess-client review")
+
+	for i, p := range plies {
+		name, suffix := classify(p.loss)
+		move := &g.Moves[i]
+		move.Suffix = suffix
+		move.Comment = strings.TrimSpace(fmt.Sprintf("%s [%%eval %s]", move.Comment, pgnEval(evals[i+1])))
+		if name != "" {
+			move.Comment += fmt.Sprintf(" %s. %s was best.", name, p.best)
+		}
+	}
+	return g
+}
+
+// pgnEval formats an evaluation for a %eval comment: pawns, or #N and #-N
+// for mate, #0 and #-0 once it is on the board
+func pgnEval(cp int) string {
+	switch s := formatScore(cp); {
+	case cp >= engine.MateScore:
+		return "#0"
+	case cp <= -engine.MateScore:
+		return "#-0"
+	case strings.HasPrefix(s, "-#"):
+		return "#-" + s[2:]
+	default:
+		return strings.TrimPrefix(s, "+")
+	}
+}
\ No newline at end of file
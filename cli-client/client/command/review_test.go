@@ -0,0 +1,100 @@
+// FILE: lixenwraith/chess/internal/client/command/review_test.go
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"chess/internal/client/api"
+)
+
+func TestReviewCommand(t *testing.T) {
+	pgnPath := filepath.Join(t.TempDir(), "review.pgn")
+	played := func(e *testEnv) { e.newGame(); e.playMoves("e2e4", "e7e5", "d1h5") }
+	unrecorded := func(e *testEnv) {
+		// Created behind the session's back, so its start is not recorded
+		game, err := e.client.CreateGame(&api.CreateGameRequest{
+			White: api.PlayerConfig{Type: api.Human},
+			Black: api.PlayerConfig{Type: api.Human},
+			FEN:   "4k3/8/8/8/8/8/8/4K2R w K - 0 1",
+		})
+		if err != nil {
+			e.t.Fatal(err)
+		}
+		if _, err := e.client.MakeMove(game.GameID, "e1g1"); err != nil {
+			e.t.Fatal(err)
+		}
+		e.gameID = game.GameID
+		e.mustRun("join {game}")
+	}
+
+	runCases(t, []commandCase{
+		{
+			name:  "annotated pgn",
+			setup: played,
+			line:  "review -time 100 -pgn " + pgnPath,
+			check: func(e *testEnv) {
+				data, err := os.ReadFile(pgnPath)
+				if err != nil {
+					e.t.Fatal(err)
+				}
+				for _, want := range []string{"1. e4", "Qh5", `[ECO "C20"]`} {
+					if !strings.Contains(string(data), want) {
+						e.t.Fatalf("pgn has no %s:\n%s", want, data)
+					}
+				}
+			},
+		},
+		{
+			name: "custom start position",
+			setup: func(e *testEnv) {
+				e.mustRun("new", "h", "h", "4k3/8/8/8/8/8/8/4K2R w K - 0 1")
+				e.gameID = e.session.GetCurrentGame()
+				e.playMoves("e1g1", "e8d7")
+			},
+			line: "v -time 100",
+		},
+		{
+			name:  "start position passed",
+			setup: unrecorded,
+			line:  "review -time 100 -fen 4k3/8/8/8/8/8/8/4K2R w K - 0 1",
+		},
+		{
+			name:    "unknown start position",
+			setup:   unrecorded,
+			line:    "review -time 100",
+			wantErr: "cannot replay",
+		},
+		{
+			name:    "wrong start position",
+			setup:   unrecorded,
+			line:    "review -time 100 -fen 4k3/8/8/8/8/8/8/R3K3 w Q - 0 1",
+			wantErr: "cannot replay",
+		},
+		{
+			name:    "invalid start position",
+			setup:   unrecorded,
+			line:    "review -time 100 -fen 4k3/8/8/8/8/8/8/8 w - - 0 1",
+			wantErr: "FEN rejected",
+		},
+		{
+			name:    "no moves",
+			setup:   func(e *testEnv) { e.newGame() },
+			line:    "review -time 100",
+			wantErr: "no moves to review",
+		},
+		{
+			name:    "search time out of range",
+			setup:   played,
+			line:    "review -time 5",
+			wantErr: "search time must be",
+		},
+		{
+			name:    "no current game",
+			line:    "review",
+			wantErr: "no current game",
+		},
+	})
+}
\ No newline at end of file
@@ -0,0 +1,86 @@
+// FILE: lixenwraith/chess/internal/client/command/stats.go
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"chess/internal/client/display"
+	"chess/internal/client/session"
+)
+
+func (r *Registry) registerStatsCommands() {
+	r.Register(&Command{
+		Name:        "stats",
+		ShortName:   "t",
+		Description: "Show per-route API latency statistics",
+		Usage:       "stats [reset]",
+		Handler:     statsHandler,
+	})
+}
+
+func statsHandler(s *session.Session, args []string) error {
+	sr, ok := s.GetBackend().(session.StatsReporter)
+	if !ok {
+		return fmt.Errorf("current backend collects no statistics")
+	}
+
+	if len(args) > 0 {
+		if args[0] != "reset" {
+			return fmt.Errorf("usage: stats [reset]")
+		}
+		sr.ResetStats()
+		display.Println(display.Green, "Statistics reset")
+		return nil
+	}
+
+	stats := sr.Stats()
+	if len(stats) == 0 {
+		display.Println(display.Yellow, "No API calls measured")
+		return nil
+	}
+
+	display.Println(display.Cyan, "API Statistics:")
+	fmt.Printf("  %-38s %6s %5s %9s %9s %9s %10s %10s\n",
+		"Route", "Calls", "Errs", "p50", "p95", "p99", "Sent", "Received")
+	for _, rs := range stats {
+		errColor := display.Reset
+		if rs.Errors > 0 {
+			errColor = display.Red
+		}
+		fmt.Printf("  %-38s %6d %s %9s %9s %9s %10s %10s\n",
+			rs.Route, rs.Count, display.C(errColor, fmt.Sprintf("%5d", rs.Errors)),
+			formatLatency(rs.Percentile(50)), formatLatency(rs.Percentile(95)), formatLatency(rs.Percentile(99)),
+			formatBytes(rs.BytesSent), formatBytes(rs.BytesReceived))
+
+		codes := make([]int, 0, len(rs.Statuses))
+		for code := range rs.Statuses {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+		parts := make([]string, 0, len(codes))
+		for _, code := range codes {
+			parts = append(parts, fmt.Sprintf("%d×%d", code, rs.Statuses[code]))
+		}
+		if len(parts) > 0 {
+			fmt.Printf("  %-38s %s\n", "", strings.Join(parts, "  "))
+		}
+	}
+	return nil
+}
+
+func formatLatency(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(d.Microseconds())/1000)
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fMiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1fKiB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%dB", n)
+}
@@ -0,0 +1,76 @@
+// FILE: lixenwraith/chess/internal/client/command/stats_test.go
+package command
+
+import (
+	"testing"
+
+	"chess/internal/client/clienttest"
+)
+
+func TestStatsCommand(t *testing.T) {
+	runCases(t, []commandCase{
+		{
+			name:  "per route",
+			setup: func(e *testEnv) { e.newGame(); e.mustRun("move e2e4") },
+			line:  "stats",
+			check: func(e *testEnv) {
+				routes := make(map[string]int)
+				for _, rs := range e.client.Stats() {
+					routes[rs.Route] = rs.Count
+				}
+				if routes["POST /api/v1/games/{id}/moves"] != 1 {
+					e.t.Fatalf("routes = %v", routes)
+				}
+			},
+		},
+		{
+			name:  "long poll apart from plain fetches",
+			setup: func(e *testEnv) { e.newGame(); e.mustRun("show"); e.mustRun("poll") },
+			line:  "stats",
+			check: func(e *testEnv) {
+				routes := make(map[string]int)
+				for _, rs := range e.client.Stats() {
+					routes[rs.Route] = rs.Count
+				}
+				if routes["GET /api/v1/games/{id}?wait"] != 1 || routes["GET /api/v1/games/{id}"] == 0 {
+					e.t.Fatalf("routes = %v", routes)
+				}
+			},
+		},
+		{
+			name: "errors counted",
+			setup: func(e *testEnv) {
+				e.fake.FailNext("GET /health", clienttest.Failure{Status: 503, Code: "UNAVAILABLE", Message: "down"})
+				e.run("health")
+			},
+			line: "t",
+			check: func(e *testEnv) {
+				stats := e.client.Stats()
+				if len(stats) != 1 || stats[0].Errors != 1 {
+					e.t.Fatalf("stats = %+v", stats)
+				}
+			},
+		},
+		{
+			name:  "reset",
+			setup: func(e *testEnv) { e.newGame() },
+			line:  "stats reset",
+			check: func(e *testEnv) {
+				if n := len(e.client.Stats()); n != 0 {
+					e.t.Fatalf("%d routes after reset", n)
+				}
+			},
+		},
+		{
+			name:    "bad argument",
+			line:    "stats clear",
+			wantErr: "usage",
+		},
+		{
+			name:    "offline backend",
+			setup:   func(e *testEnv) { e.mustRun("offline on") },
+			line:    "stats",
+			wantErr: "no statistics",
+		},
+	})
+}
\ No newline at end of file
@@ -0,0 +1,185 @@
+// FILE: lixenwraith/chess/internal/client/command/undo.go
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"chess/internal/client/api"
+	"chess/internal/client/display"
+	"chess/internal/client/session"
+)
+
+// undoConfirmPlies is the largest take-back done without asking
+const undoConfirmPlies = 4
+
+func undoHandler(s *session.Session, args []string) error {
+	gameID := s.GetCurrentGame()
+	if gameID == "" {
+		return fmt.Errorf("no current game, use 'new' or 'join <gameId>'")
+	}
+
+	confirmed := false
+	var rest []string
+	for _, arg := range args {
+		if arg == "-y" {
+			confirmed = true
+		} else {
+			rest = append(rest, arg)
+		}
+	}
+
+	c := s.GetBackend()
+	game, err := c.GetGame(gameID)
+	if err != nil {
+		return err
+	}
+	if game.State == api.StatePending {
+		return fmt.Errorf("computer move in progress, wait for it before undoing")
+	}
+
+	me := s.GetPlayerColor()
+	if seat := s.GetEngineSeat(); !me.Valid() && seat != nil && seat.GameID == gameID {
+		// The attached engine plays one human seat, so the other is ours
+		me = seat.Color.Other()
+	}
+	count, err := undoCount(game, me, rest)
+	if err != nil {
+		return err
+	}
+	target := len(game.Moves) - count
+
+	// Preview what is being taken back and where the game will resume,
+	// numbering moves from the start position when the game replays
+	number := 1
+	history := s.Replay(game)
+	if history != nil {
+		number = history.Positions()[0].Fullmove
+	}
+	display.Println(display.Cyan, "Taking back %d move(s): %s", count, formatPlies(game.Moves, target, moverAt(game, 0), number))
+	if history != nil {
+		pos := history.Positions()[target]
+		display.RenderBoard(pos.ASCII())
+		fmt.Printf("\nFEN: %s\n", pos.FEN())
+	}
+	fmt.Printf("Resumes at ply %d with %s to move\n", target, display.ColorForTurn(string(moverAt(game, target))))
+
+	if count > undoConfirmPlies && !confirmed {
+		display.Print(display.Yellow, "Undo %d moves? (y/N): ", count)
+		scanner := bufio.NewScanner(os.Stdin)
+		scanner.Scan()
+		if answer := strings.ToLower(strings.TrimSpace(scanner.Text())); answer != "y" && answer != "yes" {
+			display.Println(display.Yellow, "Undo cancelled")
+			return nil
+		}
+	}
+
+	resp, err := c.UndoMoves(gameID, count)
+	if err != nil {
+		return err
+	}
+
+	s.SetLastMoveCount(len(resp.Moves))
+	s.SetGameState(resp)
+	display.Println(display.Green, "Undid %d move(s)", count)
+	if resp.Players.Player(resp.Turn).Type == api.Computer {
+		display.Println(display.Magenta, "\nComputer's turn. Use 'computer' or 'c' to trigger move.")
+	}
+	return nil
+}
+
+// undoCount turns the undo arguments into a number of plies to take back
+func undoCount(game *api.GameResponse, me api.Color, args []string) (int, error) {
+	total := len(game.Moves)
+	if total == 0 {
+		return 0, fmt.Errorf("no moves to undo")
+	}
+
+	switch {
+	case len(args) == 0:
+		return myLastMoveCount(game, me)
+
+	case args[0] == "all":
+		return total, nil
+
+	case args[0] == "to":
+		if len(args) < 2 {
+			return 0, fmt.Errorf("usage: undo to <ply>")
+		}
+		ply, err := strconv.Atoi(args[1])
+		if err != nil || ply < 0 || ply >= total {
+			return 0, fmt.Errorf("ply must be between 0 and %d", total-1)
+		}
+		return total - ply, nil
+
+	default:
+		count, err := strconv.Atoi(args[0])
+		if err != nil {
+			return 0, fmt.Errorf("invalid count: %s", args[0])
+		}
+		if count < 1 || count > total {
+			return 0, fmt.Errorf("count must be between 1 and %d", total)
+		}
+		return count, nil
+	}
+}
+
+// myLastMoveCount counts the plies back to and including the last move made
+// by the local player, so any engine replies are taken back with it
+func myLastMoveCount(game *api.GameResponse, me api.Color) (int, error) {
+	if !me.Valid() {
+		white, black := game.Players.White.Type, game.Players.Black.Type
+		switch {
+		case white == api.Human && black != api.Human:
+			me = api.White
+		case black == api.Human && white != api.Human:
+			me = api.Black
+		case white == api.Human && black == api.Human:
+			// Both sides are played here, take back the last move
+			return 1, nil
+		default:
+			return 0, fmt.Errorf("no human player in this game, use 'undo <count>'")
+		}
+	}
+
+	for ply := len(game.Moves) - 1; ply >= 0; ply-- {
+		if moverAt(game, ply) == me {
+			return len(game.Moves) - ply, nil
+		}
+	}
+	return 0, fmt.Errorf("%s has no moves to take back", me)
+}
+
+// moverAt returns the side that plays ply (0-based) of the game
+func moverAt(game *api.GameResponse, ply int) api.Color {
+	if (len(game.Moves)-ply)%2 == 0 {
+		return game.Turn
+	}
+	return game.Turn.Other()
+}
+
+// formatPlies renders the moves from ply onwards with move numbers, given
+// the side that played the first move and its move number
+func formatPlies(moves []string, ply int, first api.Color, number int) string {
+	var b strings.Builder
+	for i := ply; i < len(moves); i++ {
+		if i > ply {
+			b.WriteString(" ")
+		}
+		abs := i
+		if first == api.Black {
+			abs++
+		}
+		if abs%2 == 0 {
+			fmt.Fprintf(&b, "%d.%s", number+abs/2, moves[i])
+		} else if i == ply {
+			fmt.Fprintf(&b, "%d...%s", number+abs/2, moves[i])
+		} else {
+			b.WriteString(moves[i])
+		}
+	}
+	return b.String()
+}
\ No newline at end of file
@@ -0,0 +1,697 @@
+// FILE: lixenwraith/chess/internal/client/conformance/checks.go
+package conformance
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/rules"
+)
+
+type check struct {
+	category string
+	name     string
+	run      func(t *T) error
+}
+
+var (
+	human    = api.PlayerConfig{Type: api.Human}
+	computer = api.PlayerConfig{Type: api.Computer, Level: 1, SearchTime: 100}
+)
+
+func checks() []check {
+	list := []check{
+		{"health", "health reports status and server time", checkHealth},
+	}
+
+	combos := []struct {
+		name         string
+		white, black api.PlayerConfig
+	}{
+		{"human vs human", human, human},
+		{"human vs computer", human, computer},
+		{"computer vs human", computer, human},
+		{"computer vs computer", computer, computer},
+	}
+	for _, combo := range combos {
+		list = append(list, check{"games", "create " + combo.name, func(t *T) error {
+			return checkCreate(t, combo.white, combo.black)
+		}})
+	}
+
+	return append(list,
+		check{"games", "create from custom FEN", checkCreateFEN},
+		check{"games", "invalid FEN rejected", checkInvalidFEN},
+		check{"games", "invalid player type rejected", checkInvalidPlayer},
+		check{"games", "get returns created game", checkGetGame},
+		check{"games", "board matches FEN", checkBoard},
+		check{"games", "unknown game returns 404", checkUnknownGame},
+		check{"games", "deleted game returns 404", checkDelete},
+
+		check{"moves", "legal move updates game", checkLegalMove},
+		check{"moves", "illegal move rejected", checkIllegalMove},
+		check{"moves", "malformed move rejected", checkMalformedMove},
+		check{"moves", "move out of turn rejected", checkOutOfTurn},
+		check{"moves", "promotion applied", checkPromotion},
+
+		check{"undo", "undo restores previous positions", checkUndo},
+		check{"undo", "undo beyond history rejected", checkUndoTooMany},
+		check{"undo", "undo of zero moves rejected", checkUndoZero},
+
+		check{"poll", "stale move count returns immediately", checkPollStale},
+		check{"poll", "poll wakes on move", checkPollWakes},
+		check{"poll", "poll returns unchanged game on timeout", checkPollTimeout},
+
+		check{"engine", "computer move passes through pending", checkComputerMove},
+		check{"engine", "computer trigger rejected on human turn", checkTriggerHumanTurn},
+		check{"engine", "human move rejected on computer turn", checkMoveComputerTurn},
+
+		check{"states", "checkmate ends the game", checkCheckmate},
+		check{"states", "stalemate ends the game", checkStalemate},
+
+		check{"auth", "register returns token", checkRegister},
+		check{"auth", "duplicate username rejected", checkDuplicateUser},
+		check{"auth", "login and current user", checkLogin},
+		check{"auth", "current user without token returns 401", checkMeAnonymous},
+		check{"auth", "wrong password returns 401", checkWrongPassword},
+		check{"auth", "authenticated game records player", checkAuthGame},
+	)
+}
+
+// Assertion helpers
+
+func checkState(state api.GameState) error {
+	if !state.Known() {
+		return fmt.Errorf("unknown game state %q", state)
+	}
+	return nil
+}
+
+// expectError verifies err is an API error with a status in [lo, hi] and a
+// well-formed ErrorResponse body
+func expectError(err error, lo, hi int) error {
+	if err == nil {
+		return fmt.Errorf("expected status %s, request succeeded", statusRange(lo, hi))
+	}
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) {
+		return fmt.Errorf("expected status %s, got %v", statusRange(lo, hi), err)
+	}
+	if apiErr.Status < lo || apiErr.Status > hi {
+		return fmt.Errorf("expected status %s, got %d", statusRange(lo, hi), apiErr.Status)
+	}
+	if apiErr.Response == nil {
+		return fmt.Errorf("status %d: body is not an error response: %q", apiErr.Status, apiErr.Body)
+	}
+	if apiErr.Response.Error == "" || apiErr.Response.Code == "" {
+		return fmt.Errorf("status %d: error response missing error or code: %q", apiErr.Status, apiErr.Body)
+	}
+	return nil
+}
+
+func statusRange(lo, hi int) string {
+	if lo == hi {
+		return fmt.Sprint(lo)
+	}
+	if lo == 400 && hi == 499 {
+		return "4xx"
+	}
+	return fmt.Sprintf("%d-%d", lo, hi)
+}
+
+// expectPosition compares placement, side to move and castling rights, which
+// every server must agree on; clocks and en passant conventions may differ
+func expectPosition(got, want string) error {
+	g, w := strings.Fields(got), strings.Fields(want)
+	if len(g) < 3 || len(w) < 3 || strings.Join(g[:3], " ") != strings.Join(w[:3], " ") {
+		return fmt.Errorf("expected FEN %q, got %q", want, got)
+	}
+	return nil
+}
+
+// play applies moves from the start position and returns the resulting FEN
+func play(moves ...string) string {
+	pos := rules.Start()
+	for _, uci := range moves {
+		m, err := pos.ParseMove(uci)
+		if err != nil {
+			panic(err)
+		}
+		pos = pos.Apply(m)
+	}
+	return pos.FEN()
+}
+
+func (t *T) newHumanGame() (*api.GameResponse, error) {
+	return t.createGame(t.client, &api.CreateGameRequest{White: human, Black: human})
+}
+
+func (t *T) moves(gameID string, moves ...string) (*api.GameResponse, error) {
+	var game *api.GameResponse
+	for _, m := range moves {
+		var err error
+		if game, err = t.client.MakeMove(gameID, m); err != nil {
+			return nil, fmt.Errorf("move %s: %w", m, err)
+		}
+		if err := checkState(game.State); err != nil {
+			return nil, err
+		}
+	}
+	return game, nil
+}
+
+// awaitSettled polls until the game leaves the pending state
+func (t *T) awaitSettled(gameID string) (*api.GameResponse, error) {
+	deadline := time.Now().Add(t.cfg.EngineTimeout)
+	for {
+		game, err := t.client.GetGame(gameID)
+		if err != nil {
+			return nil, fmt.Errorf("get game: %w", err)
+		}
+		if err := checkState(game.State); err != nil {
+			return nil, err
+		}
+		if game.State != api.StatePending {
+			return game, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("game still pending after %s", t.cfg.EngineTimeout)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func (t *T) register() (*api.AuthResponse, string, error) {
+	if t.cfg.SkipAuth {
+		return nil, "", errSkip("authentication checks disabled")
+	}
+	username := "conformance-" + newID()
+	password := newID() + newID()
+	auth, err := t.client.Register(username, password, "")
+	if err != nil {
+		return nil, "", fmt.Errorf("register: %w", err)
+	}
+	if auth.Username != username {
+		return nil, "", fmt.Errorf("register: expected username %q, got %q", username, auth.Username)
+	}
+	return auth, password, nil
+}
+
+// Checks
+
+func checkHealth(t *T) error {
+	h, err := t.client.Health()
+	if err != nil {
+		return err
+	}
+	if h.Status == "" {
+		return fmt.Errorf("empty status")
+	}
+	skew := time.Since(time.Unix(h.Time, 0))
+	if skew < -5*time.Minute || skew > 5*time.Minute {
+		return fmt.Errorf("server time %d is %s away from local time", h.Time, skew.Round(time.Second))
+	}
+	return nil
+}
+
+func checkCreate(t *T, white, black api.PlayerConfig) error {
+	game, err := t.createGame(t.client, &api.CreateGameRequest{White: white, Black: black})
+	if err != nil {
+		return err
+	}
+	if err := expectPosition(game.FEN, rules.StartFEN); err != nil {
+		return err
+	}
+	if game.Turn != api.White {
+		return fmt.Errorf("expected turn w, got %q", string(game.Turn))
+	}
+	if white.Type == api.Human && (game.State != api.StateOngoing || len(game.Moves) != 0) {
+		return fmt.Errorf("expected ongoing game without moves, got %s with %d moves", game.State, len(game.Moves))
+	}
+	for _, side := range []struct {
+		color string
+		want  api.PlayerConfig
+		got   api.PlayerInfo
+	}{
+		{"white", white, game.Players.White},
+		{"black", black, game.Players.Black},
+	} {
+		if side.got.Type != side.want.Type {
+			return fmt.Errorf("%s: expected %s player, got %s", side.color, side.want.Type, side.got.Type)
+		}
+		if side.want.Type == api.Computer && side.got.Level != side.want.Level {
+			return fmt.Errorf("%s: expected level %d, got %d", side.color, side.want.Level, side.got.Level)
+		}
+	}
+	return nil
+}
+
+func checkCreateFEN(t *T) error {
+	fen := "r3k2r/8/8/8/8/8/8/R3K2R b KQkq - 0 1"
+	game, err := t.createGame(t.client, &api.CreateGameRequest{White: human, Black: human, FEN: fen})
+	if err != nil {
+		return err
+	}
+	if err := expectPosition(game.FEN, fen); err != nil {
+		return err
+	}
+	if game.Turn != api.Black {
+		return fmt.Errorf("expected turn b, got %q", string(game.Turn))
+	}
+	return nil
+}
+
+func checkInvalidFEN(t *T) error {
+	_, err := t.client.CreateGame(&api.CreateGameRequest{White: human, Black: human, FEN: "not a fen"})
+	return expectError(err, 400, 400)
+}
+
+func checkInvalidPlayer(t *T) error {
+	_, err := t.client.CreateGame(&api.CreateGameRequest{White: api.PlayerConfig{Type: 7}, Black: human})
+	return expectError(err, 400, 499)
+}
+
+func checkGetGame(t *T) error {
+	created, err := t.newHumanGame()
+	if err != nil {
+		return err
+	}
+	game, err := t.client.GetGame(created.GameID)
+	if err != nil {
+		return err
+	}
+	if game.GameID != created.GameID || game.FEN != created.FEN || game.Turn != created.Turn {
+		return fmt.Errorf("get returned %s %q, created %s %q", game.GameID, game.FEN, created.GameID, created.FEN)
+	}
+	return nil
+}
+
+func checkBoard(t *T) error {
+	game, err := t.newHumanGame()
+	if err != nil {
+		return err
+	}
+	board, err := t.client.GetBoard(game.GameID)
+	if err != nil {
+		return err
+	}
+	if board.FEN != game.FEN {
+		return fmt.Errorf("board FEN %q differs from game FEN %q", board.FEN, game.FEN)
+	}
+	if strings.Count(board.Board, "\n") < 8 {
+		return fmt.Errorf("board has fewer than 8 lines: %q", board.Board)
+	}
+	return nil
+}
+
+func checkUnknownGame(t *T) error {
+	_, err := t.client.GetGame("00000000-0000-0000-0000-000000000000")
+	return expectError(err, 404, 404)
+}
+
+func checkDelete(t *T) error {
+	game, err := t.newHumanGame()
+	if err != nil {
+		return err
+	}
+	if err := t.client.DeleteGame(game.GameID); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+	_, err = t.client.GetGame(game.GameID)
+	return expectError(err, 404, 404)
+}
+
+func checkLegalMove(t *T) error {
+	game, err := t.newHumanGame()
+	if err != nil {
+		return err
+	}
+	game, err = t.moves(game.GameID, "e2e4")
+	if err != nil {
+		return err
+	}
+	if err := expectPosition(game.FEN, play("e2e4")); err != nil {
+		return err
+	}
+	if game.Turn != api.Black {
+		return fmt.Errorf("expected turn b, got %q", string(game.Turn))
+	}
+	if len(game.Moves) != 1 || game.Moves[0] != "e2e4" {
+		return fmt.Errorf("expected moves [e2e4], got %v", game.Moves)
+	}
+	if game.LastMove != nil && (game.LastMove.Move != "e2e4" || game.LastMove.PlayerColor != api.White) {
+		return fmt.Errorf("unexpected lastMove %+v", *game.LastMove)
+	}
+	return nil
+}
+
+func checkIllegalMove(t *T) error {
+	game, err := t.newHumanGame()
+	if err != nil {
+		return err
+	}
+	_, err = t.client.MakeMove(game.GameID, "e2e5")
+	if err := expectError(err, 400, 499); err != nil {
+		return err
+	}
+	after, err := t.client.GetGame(game.GameID)
+	if err != nil {
+		return err
+	}
+	if after.FEN != game.FEN || len(after.Moves) != 0 {
+		return fmt.Errorf("rejected move changed the game: %q", after.FEN)
+	}
+	return nil
+}
+
+func checkMalformedMove(t *T) error {
+	game, err := t.newHumanGame()
+	if err != nil {
+		return err
+	}
+	_, err = t.client.MakeMove(game.GameID, "zz")
+	return expectError(err, 400, 499)
+}
+
+func checkOutOfTurn(t *T) error {
+	game, err := t.newHumanGame()
+	if err != nil {
+		return err
+	}
+	_, err = t.client.MakeMove(game.GameID, "e7e5")
+	return expectError(err, 400, 499)
+}
+
+func checkPromotion(t *T) error {
+	game, err := t.createGame(t.client, &api.CreateGameRequest{
+		White: human, Black: human, FEN: "8/P6k/8/8/8/8/8/K7 w - - 0 1",
+	})
+	if err != nil {
+		return err
+	}
+	game, err = t.moves(game.GameID, "a7a8q")
+	if err != nil {
+		return err
+	}
+	return expectPosition(game.FEN, "Q7/7k/8/8/8/8/8/K7 b - - 0 1")
+}
+
+func checkUndo(t *T) error {
+	game, err := t.newHumanGame()
+	if err != nil {
+		return err
+	}
+	if _, err := t.moves(game.GameID, "e2e4", "e7e5"); err != nil {
+		return err
+	}
+	game, err = t.client.UndoMoves(game.GameID, 1)
+	if err != nil {
+		return fmt.Errorf("undo 1: %w", err)
+	}
+	if err := expectPosition(game.FEN, play("e2e4")); err != nil {
+		return fmt.Errorf("after undo 1: %w", err)
+	}
+	if len(game.Moves) != 1 {
+		return fmt.Errorf("after undo 1: expected 1 move, got %v", game.Moves)
+	}
+	game, err = t.client.UndoMoves(game.GameID, 1)
+	if err != nil {
+		return fmt.Errorf("undo 2: %w", err)
+	}
+	if err := expectPosition(game.FEN, rules.StartFEN); err != nil {
+		return fmt.Errorf("after undo 2: %w", err)
+	}
+	return nil
+}
+
+func checkUndoTooMany(t *T) error {
+	game, err := t.newHumanGame()
+	if err != nil {
+		return err
+	}
+	if _, err := t.moves(game.GameID, "e2e4"); err != nil {
+		return err
+	}
+	_, err = t.client.UndoMoves(game.GameID, 5)
+	return expectError(err, 400, 499)
+}
+
+func checkUndoZero(t *T) error {
+	game, err := t.newHumanGame()
+	if err != nil {
+		return err
+	}
+	if _, err := t.moves(game.GameID, "e2e4"); err != nil {
+		return err
+	}
+	_, err = t.client.UndoMoves(game.GameID, 0)
+	return expectError(err, 400, 499)
+}
+
+func checkPollStale(t *T) error {
+	game, err := t.newHumanGame()
+	if err != nil {
+		return err
+	}
+	if _, err := t.moves(game.GameID, "e2e4"); err != nil {
+		return err
+	}
+	start := time.Now()
+	game, err = t.client.GetGameWithPoll(game.GameID, 0)
+	if err != nil {
+		return err
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		return fmt.Errorf("poll with stale move count took %s", elapsed.Round(time.Millisecond))
+	}
+	if len(game.Moves) != 1 {
+		return fmt.Errorf("expected 1 move, got %v", game.Moves)
+	}
+	return nil
+}
+
+func checkPollWakes(t *T) error {
+	game, err := t.newHumanGame()
+	if err != nil {
+		return err
+	}
+
+	type result struct {
+		game    *api.GameResponse
+		err     error
+		elapsed time.Duration
+	}
+	done := make(chan result, 1)
+	poller := t.newClient()
+	go func() {
+		start := time.Now()
+		g, err := poller.GetGameWithPoll(game.GameID, 0)
+		done <- result{g, err, time.Since(start)}
+	}()
+
+	const delay = 500 * time.Millisecond
+	time.Sleep(delay)
+	if _, err := t.moves(game.GameID, "d2d4"); err != nil {
+		return err
+	}
+
+	select {
+	case res := <-done:
+		if res.err != nil {
+			return res.err
+		}
+		if res.elapsed < delay/2 {
+			return fmt.Errorf("poll returned after %s, before the move was made", res.elapsed.Round(time.Millisecond))
+		}
+		if len(res.game.Moves) != 1 || res.game.Moves[0] != "d2d4" {
+			return fmt.Errorf("poll returned moves %v, expected [d2d4]", res.game.Moves)
+		}
+		return nil
+	case <-time.After(3 * time.Second):
+		return fmt.Errorf("poll did not return within 3s of the move")
+	}
+}
+
+func checkPollTimeout(t *T) error {
+	if !t.cfg.Slow {
+		return errSkip("waits for the server poll timeout; enable slow checks")
+	}
+	game, err := t.newHumanGame()
+	if err != nil {
+		return err
+	}
+	got, err := t.client.GetGameWithPoll(game.GameID, 0)
+	if err != nil {
+		return err
+	}
+	if got.FEN != game.FEN || len(got.Moves) != 0 {
+		return fmt.Errorf("poll timeout returned a changed game: %q", got.FEN)
+	}
+	return nil
+}
+
+func checkComputerMove(t *T) error {
+	game, err := t.createGame(t.client, &api.CreateGameRequest{White: human, Black: computer})
+	if err != nil {
+		return err
+	}
+	if _, err := t.moves(game.GameID, "e2e4"); err != nil {
+		return err
+	}
+	trigger, err := t.client.MakeMove(game.GameID, "cccc")
+	if err != nil {
+		return fmt.Errorf("trigger: %w", err)
+	}
+	if err := checkState(trigger.State); err != nil {
+		return err
+	}
+	if trigger.State != api.StatePending && len(trigger.Moves) != 2 {
+		return fmt.Errorf("trigger returned %s with %d moves, expected pending", trigger.State, len(trigger.Moves))
+	}
+
+	game, err = t.awaitSettled(game.GameID)
+	if err != nil {
+		return err
+	}
+	if game.State != api.StateOngoing || len(game.Moves) != 2 || game.Turn != api.White {
+		return fmt.Errorf("after computer move: state %s, turn %s, moves %v", game.State, game.Turn, game.Moves)
+	}
+	if game.LastMove == nil || game.LastMove.PlayerColor != api.Black {
+		return fmt.Errorf("lastMove does not record the computer move: %+v", game.LastMove)
+	}
+	return nil
+}
+
+func checkTriggerHumanTurn(t *T) error {
+	game, err := t.newHumanGame()
+	if err != nil {
+		return err
+	}
+	_, err = t.client.MakeMove(game.GameID, "cccc")
+	return expectError(err, 400, 499)
+}
+
+func checkMoveComputerTurn(t *T) error {
+	game, err := t.createGame(t.client, &api.CreateGameRequest{White: computer, Black: human})
+	if err != nil {
+		return err
+	}
+	_, err = t.client.MakeMove(game.GameID, "e2e4")
+	return expectError(err, 400, 499)
+}
+
+func checkCheckmate(t *T) error {
+	game, err := t.newHumanGame()
+	if err != nil {
+		return err
+	}
+	game, err = t.moves(game.GameID, "f2f3", "e7e5", "g2g4", "d8h4")
+	if err != nil {
+		return err
+	}
+	if !game.State.IsOver() || game.State.Winner() != api.Black {
+		return fmt.Errorf("expected black to win by checkmate, got %s", game.State)
+	}
+	_, err = t.client.MakeMove(game.GameID, "a2a3")
+	if err := expectError(err, 400, 499); err != nil {
+		return fmt.Errorf("move after mate: %w", err)
+	}
+	return nil
+}
+
+func checkStalemate(t *T) error {
+	game, err := t.createGame(t.client, &api.CreateGameRequest{
+		White: human, Black: human, FEN: "7k/8/6K1/8/8/8/8/5Q2 w - - 0 1",
+	})
+	if err != nil {
+		return err
+	}
+	game, err = t.moves(game.GameID, "f1f7")
+	if err != nil {
+		return err
+	}
+	if game.State != api.StateStalemate {
+		return fmt.Errorf("expected stalemate, got %s", game.State)
+	}
+	return nil
+}
+
+func checkRegister(t *T) error {
+	auth, _, err := t.register()
+	if err != nil {
+		return err
+	}
+	if auth.Token == "" || auth.UserID == "" {
+		return fmt.Errorf("register response missing token or userId")
+	}
+	return nil
+}
+
+func checkDuplicateUser(t *T) error {
+	auth, _, err := t.register()
+	if err != nil {
+		return err
+	}
+	_, err = t.client.Register(auth.Username, newID()+newID(), "")
+	return expectError(err, 400, 499)
+}
+
+func checkLogin(t *T) error {
+	auth, password, err := t.register()
+	if err != nil {
+		return err
+	}
+	login, err := t.client.Login(auth.Username, password)
+	if err != nil {
+		return fmt.Errorf("login: %w", err)
+	}
+	if login.Token == "" || login.UserID != auth.UserID {
+		return fmt.Errorf("login returned user %q, registered %q", login.UserID, auth.UserID)
+	}
+
+	t.client.SetToken(login.Token)
+	me, err := t.client.GetCurrentUser()
+	if err != nil {
+		return fmt.Errorf("current user: %w", err)
+	}
+	if me.UserID != auth.UserID || me.Username != auth.Username {
+		return fmt.Errorf("current user is %s (%s), expected %s (%s)", me.Username, me.UserID, auth.Username, auth.UserID)
+	}
+	return nil
+}
+
+func checkMeAnonymous(t *T) error {
+	if t.cfg.SkipAuth {
+		return errSkip("authentication checks disabled")
+	}
+	_, err := t.client.GetCurrentUser()
+	return expectError(err, 401, 401)
+}
+
+func checkWrongPassword(t *T) error {
+	auth, _, err := t.register()
+	if err != nil {
+		return err
+	}
+	_, err = t.client.Login(auth.Username, "wrong-"+newID())
+	return expectError(err, 401, 401)
+}
+
+func checkAuthGame(t *T) error {
+	auth, _, err := t.register()
+	if err != nil {
+		return err
+	}
+	t.client.SetToken(auth.Token)
+	game, err := t.newHumanGame()
+	if err != nil {
+		return err
+	}
+	if game.Players.White.ID != auth.UserID && game.Players.Black.ID != auth.UserID {
+		return fmt.Errorf("neither player is the authenticated user %s", auth.UserID)
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+// FILE: lixenwraith/chess/internal/client/conformance/conformance.go
+// Package conformance runs an executable specification of the chess server
+// API against any base URL.
+package conformance
+
+import (
+	"crypto/rand"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"chess/internal/client/api"
+)
+
+// Config selects the server and the optional parts of the suite
+type Config struct {
+	BaseURL       string
+	EngineTimeout time.Duration // how long to wait for a computer move
+	Slow          bool          // include checks that wait out the long-poll timeout
+	SkipAuth      bool
+}
+
+// Result is the outcome of a single check
+type Result struct {
+	Category string
+	Name     string
+	Passed   bool
+	Skipped  bool
+	Message  string
+	Duration time.Duration
+}
+
+// Report collects the results of a run
+type Report struct {
+	BaseURL string
+	Started time.Time
+	Elapsed time.Duration
+	Results []Result
+}
+
+// Counts returns the number of passed, failed and skipped checks
+func (r *Report) Counts() (passed, failed, skipped int) {
+	for _, res := range r.Results {
+		switch {
+		case res.Skipped:
+			skipped++
+		case res.Passed:
+			passed++
+		default:
+			failed++
+		}
+	}
+	return
+}
+
+// errSkip marks a check as not applicable to this run
+type errSkip string
+
+func (e errSkip) Error() string { return string(e) }
+
+// T carries the client and cleanup state for one check
+type T struct {
+	cfg    Config
+	client *api.Client
+	games  []string
+}
+
+func (t *T) newClient() *api.Client {
+	c := api.New(t.cfg.BaseURL)
+	c.SetQuiet(true)
+	return c
+}
+
+// createGame creates a game and schedules it for deletion
+func (t *T) createGame(c *api.Client, req *api.CreateGameRequest) (*api.GameResponse, error) {
+	game, err := c.CreateGame(req)
+	if err != nil {
+		return nil, fmt.Errorf("create game: %w", err)
+	}
+	if game.GameID == "" {
+		return nil, fmt.Errorf("create game: empty gameId")
+	}
+	t.games = append(t.games, game.GameID)
+	if err := checkState(game.State); err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+func (t *T) cleanup() {
+	for _, id := range t.games {
+		t.client.DeleteGame(id)
+	}
+}
+
+// Run executes every check in order, reporting each result as it completes
+func Run(cfg Config, progress func(Result)) *Report {
+	if cfg.EngineTimeout <= 0 {
+		cfg.EngineTimeout = 15 * time.Second
+	}
+
+	report := &Report{BaseURL: cfg.BaseURL, Started: time.Now()}
+	for _, c := range checks() {
+		t := &T{cfg: cfg}
+		t.client = t.newClient()
+
+		res := Result{Category: c.category, Name: c.name}
+		start := time.Now()
+		err := runCheck(c, t)
+		res.Duration = time.Since(start)
+
+		var skip errSkip
+		switch {
+		case err == nil:
+			res.Passed = true
+		case errors.As(err, &skip):
+			res.Skipped = true
+			res.Message = skip.Error()
+		default:
+			res.Message = err.Error()
+		}
+
+		report.Results = append(report.Results, res)
+		if progress != nil {
+			progress(res)
+		}
+	}
+	report.Elapsed = time.Since(report.Started)
+	return report
+}
+
+func runCheck(c check, t *T) (err error) {
+	defer t.cleanup()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return c.run(t)
+}
+
+// newID returns a short random identifier for users created by the suite
+func newID() string {
+	var b [4]byte
+	rand.Read(b[:])
+	return fmt.Sprintf("%x", b)
+}
+
+// JUnit XML document types
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Time     float64      `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Skipped   int         `xml:"skipped,attr"`
+	Time      float64     `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr"`
+	Cases     []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit renders the report as JUnit XML, one suite per category
+func (r *Report) WriteJUnit(w io.Writer) error {
+	passed, failed, skipped := r.Counts()
+	doc := junitSuites{
+		Name:     "chess-api-conformance",
+		Tests:    passed + failed + skipped,
+		Failures: failed,
+		Skipped:  skipped,
+		Time:     r.Elapsed.Seconds(),
+	}
+
+	index := make(map[string]int)
+	for _, res := range r.Results {
+		i, ok := index[res.Category]
+		if !ok {
+			i = len(doc.Suites)
+			index[res.Category] = i
+			doc.Suites = append(doc.Suites, junitSuite{
+				Name:      res.Category,
+				Timestamp: r.Started.UTC().Format("2006-01-02T15:04:05"),
+			})
+		}
+		suite := &doc.Suites[i]
+
+		tc := junitCase{
+			Name:      res.Name,
+			Classname: "conformance." + res.Category,
+			Time:      res.Duration.Seconds(),
+		}
+		switch {
+		case res.Skipped:
+			tc.Skipped = &junitMessage{Message: res.Message}
+			suite.Skipped++
+		case !res.Passed:
+			tc.Failure = &junitMessage{Message: res.Message, Text: res.Message}
+			suite.Failures++
+		}
+		suite.Tests++
+		suite.Time += res.Duration.Seconds()
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
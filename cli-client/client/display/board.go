@@ -8,6 +8,35 @@ import (
 
 // RenderBoard renders an ASCII board with colored pieces
 func RenderBoard(asciiBoard string) {
+	renderBoard(asciiBoard, nil)
+}
+
+// RenderBoardArrow renders the board with a move drawn on it: the from and
+// to squares in reverse video and the empty squares a sliding move crosses
+// marked with '*'. Squares are names such as "e2"
+func RenderBoardArrow(asciiBoard, from, to string) {
+	marks := map[[2]int]string{}
+	f1, r1, ok1 := squareCoords(from)
+	f2, r2, ok2 := squareCoords(to)
+	if ok1 && ok2 {
+		marks[[2]int{f1, r1}] = Reverse
+		marks[[2]int{f2, r2}] = Reverse
+		// Straight and diagonal moves get a shaft between the two squares
+		df, dr := f2-f1, r2-r1
+		if df == 0 || dr == 0 || df == dr || df == -dr {
+			sf, sr := sign(df), sign(dr)
+			for f, r := f1+sf, r1+sr; f != f2 || r != r2; f, r = f+sf, r+sr {
+				marks[[2]int{f, r}] = Yellow
+			}
+		}
+	}
+	renderBoard(asciiBoard, marks)
+}
+
+// renderBoard prints the board, applying marks keyed by file and rank
+// (0-7) to the squares of the layout rules.Position.ASCII produces: rank
+// lines "8 r n b ... 8" below a file header
+func renderBoard(asciiBoard string, marks map[[2]int]string) {
 	lines := strings.Split(asciiBoard, "\n")
 
 	for i, line := range lines {
@@ -18,7 +47,17 @@ func RenderBoard(asciiBoard string) {
 		isRankLine := (i == 0) || (i == 9)
 
 		// Process each character
-		for _, char := range line {
+		for j, char := range line {
+			if !isRankLine && j >= 2 && j%2 == 0 && j <= 16 {
+				if mark, ok := marks[[2]int{(j - 2) / 2, 8 - i}]; ok {
+					if mark == Yellow && char == '.' {
+						Print(Yellow, "*")
+					} else {
+						Print(mark, "%c", char)
+					}
+					continue
+				}
+			}
 			switch {
 			case char >= 'a' && char <= 'h' && isRankLine:
 				// File letters - Cyan
@@ -45,6 +84,23 @@ func RenderBoard(asciiBoard string) {
 	}
 }
 
+func squareCoords(sq string) (file, rank int, ok bool) {
+	if len(sq) != 2 || sq[0] < 'a' || sq[0] > 'h' || sq[1] < '1' || sq[1] > '8' {
+		return 0, 0, false
+	}
+	return int(sq[0] - 'a'), int(sq[1] - '1'), true
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+	return 0
+}
+
 // ColorForTurn returns colored turn indicator
 func ColorForTurn(turn string) string {
 	if turn == "w" {
@@ -16,6 +16,7 @@ const (
 	Magenta = "\033[35m"
 	Cyan    = "\033[36m"
 	White   = "\033[37m"
+	Reverse = "\033[7m"
 )
 
 // C wraps text with color and reset codes
@@ -0,0 +1,141 @@
+// FILE: lixenwraith/chess/internal/client/eco/eco.go
+// Package eco classifies openings by the Encyclopaedia of Chess Openings
+// from a small built-in book, matching positions by hash so transpositions
+// are recognised.
+package eco
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"chess/internal/client/rules"
+)
+
+// Opening is a named book line
+type Opening struct {
+	ECO  string
+	Name string
+}
+
+func (o Opening) String() string {
+	return o.ECO + " " + o.Name
+}
+
+// node is a position on some book line
+type node struct {
+	opening Opening // its own name, else the nearest named position before it
+	named   bool
+	lines   int // book lines passing through
+}
+
+// Continuation is a move from a position that stays in the book
+type Continuation struct {
+	Move    rules.Move
+	SAN     string
+	Opening Opening // the opening the move leads into
+	Named   bool    // the position reached has its own name
+	Lines   int     // book lines through the position reached
+}
+
+var (
+	bookOnce sync.Once
+	book     map[uint64]*node
+)
+
+// nodes builds the book from the table on first use
+func nodes() map[uint64]*node {
+	bookOnce.Do(func() {
+		var err error
+		if book, err = build(); err != nil {
+			panic(err)
+		}
+	})
+	return book
+}
+
+func build() (map[uint64]*node, error) {
+	lines := make([][]uint64, len(table))
+	named := make(map[uint64]Opening)
+	for i, entry := range table {
+		pos := rules.Start()
+		for _, san := range strings.Fields(entry.moves) {
+			m, err := pos.ParseSAN(san)
+			if err != nil {
+				return nil, fmt.Errorf("eco %s %s: %v", entry.eco, entry.name, err)
+			}
+			pos = pos.Apply(m)
+			lines[i] = append(lines[i], pos.Hash())
+		}
+		// The first entry listed names a position reached by transposition
+		hash := lines[i][len(lines[i])-1]
+		if _, ok := named[hash]; !ok {
+			named[hash] = Opening{ECO: entry.eco, Name: entry.name}
+		}
+	}
+
+	nodes := make(map[uint64]*node)
+	for _, hashes := range lines {
+		var inherited Opening
+		for _, hash := range hashes {
+			n := nodes[hash]
+			if n == nil {
+				n = &node{opening: inherited}
+				if o, ok := named[hash]; ok {
+					n.opening, n.named = o, true
+				}
+				nodes[hash] = n
+			}
+			n.lines++
+			inherited = n.opening
+		}
+	}
+	return nodes, nil
+}
+
+// Lookup returns the opening the book names for a position
+func Lookup(pos *rules.Position) (Opening, bool) {
+	if n := nodes()[pos.Hash()]; n != nil && n.opening.ECO != "" {
+		return n.opening, true
+	}
+	return Opening{}, false
+}
+
+// Classify names the opening of a game from its positions, start first: the
+// deepest position in the book decides, and its index is returned with it
+func Classify(positions []*rules.Position) (Opening, int, bool) {
+	for i := len(positions) - 1; i >= 0; i-- {
+		if o, ok := Lookup(positions[i]); ok {
+			return o, i, true
+		}
+	}
+	return Opening{}, 0, false
+}
+
+// Continuations lists the legal moves from a position that lead to a book
+// position, the most played lines first
+func Continuations(pos *rules.Position) []Continuation {
+	book := nodes()
+	var out []Continuation
+	for _, m := range pos.LegalMoves() {
+		n := book[pos.Apply(m).Hash()]
+		if n == nil {
+			continue
+		}
+		out = append(out, Continuation{
+			Move:    m,
+			SAN:     pos.SAN(m),
+			Opening: n.opening,
+			Named:   n.named,
+			Lines:   n.lines,
+		})
+	}
+	sort.SliceStable(out, func(a, b int) bool {
+		if out[a].Lines != out[b].Lines {
+			return out[a].Lines > out[b].Lines
+		}
+		return out[a].SAN < out[b].SAN
+	})
+	return out
+}
\ No newline at end of file
@@ -0,0 +1,189 @@
+// FILE: lixenwraith/chess/internal/client/eco/table.go
+package eco
+
+// table is the opening book: ECO code, name and the moves from the
+// standard start in SAN. Where two lines reach the same position the
+// first one listed names it
+var table = []struct {
+	eco, name, moves string
+}{
+	// A: flank openings, irregular queen's pawn defences, Benoni, Dutch
+	{"A00", "Polish Opening", "b4"},
+	{"A00", "Grob Opening", "g4"},
+	{"A00", "Van't Kruijs Opening", "e3"},
+	{"A00", "Mieses Opening", "d3"},
+	{"A00", "Hungarian Opening", "g3"},
+	{"A00", "Saragossa Opening", "c3"},
+	{"A00", "Van Geet Opening", "Nc3"},
+	{"A01", "Nimzo-Larsen Attack", "b3"},
+	{"A02", "Bird Opening", "f4"},
+	{"A02", "Bird Opening: From's Gambit", "f4 e5"},
+	{"A03", "Bird Opening: Dutch Variation", "f4 d5"},
+	{"A04", "Zukertort Opening", "Nf3"},
+	{"A04", "Zukertort Opening: Sicilian Invitation", "Nf3 c5"},
+	{"A05", "Zukertort Opening: Indian Variation", "Nf3 Nf6"},
+	{"A06", "Zukertort Opening: Queen's Gambit Invitation", "Nf3 d5"},
+	{"A07", "King's Indian Attack", "Nf3 d5 g3"},
+	{"A09", "Réti Opening", "Nf3 d5 c4"},
+	{"A10", "English Opening", "c4"},
+	{"A13", "English Opening: Agincourt Defense", "c4 e6"},
+	{"A15", "English Opening: Anglo-Indian Defense", "c4 Nf6"},
+	{"A16", "English Opening: Anglo-Indian Defense, Queen's Knight Variation", "c4 Nf6 Nc3"},
+	{"A20", "English Opening: King's English Variation", "c4 e5"},
+	{"A22", "English Opening: King's English Variation, Two Knights Variation", "c4 e5 Nc3 Nf6"},
+	{"A25", "English Opening: King's English Variation, Reversed Closed Sicilian", "c4 e5 Nc3 Nc6"},
+	{"A30", "English Opening: Symmetrical Variation", "c4 c5"},
+	{"A40", "Queen's Pawn Game", "d4"},
+	{"A40", "Englund Gambit", "d4 e5"},
+	{"A40", "Horwitz Defense", "d4 e6"},
+	{"A41", "Queen's Pawn Game: Modern Defense", "d4 g6"},
+	{"A43", "Benoni Defense: Old Benoni", "d4 c5"},
+	{"A45", "Indian Defense", "d4 Nf6"},
+	{"A45", "Trompowsky Attack", "d4 Nf6 Bg5"},
+	{"A46", "Indian Defense: Knights Variation", "d4 Nf6 Nf3"},
+	{"A48", "East Indian Defense", "d4 Nf6 Nf3 g6"},
+	{"A50", "Indian Defense: Normal Variation", "d4 Nf6 c4"},
+	{"A51", "Budapest Defense", "d4 Nf6 c4 e5"},
+	{"A56", "Benoni Defense", "d4 Nf6 c4 c5"},
+	{"A57", "Benko Gambit", "d4 Nf6 c4 c5 d5 b5"},
+	{"A60", "Benoni Defense: Modern Variation", "d4 Nf6 c4 c5 d5 e6"},
+	{"A80", "Dutch Defense", "d4 f5"},
+	{"A82", "Dutch Defense: Staunton Gambit", "d4 f5 e4"},
+	{"A84", "Dutch Defense", "d4 f5 c4"},
+
+	// B: semi-open games other than the French
+	{"B00", "King's Pawn Game", "e4"},
+	{"B00", "Nimzowitsch Defense", "e4 Nc6"},
+	{"B00", "Owen Defense", "e4 b6"},
+	{"B01", "Scandinavian Defense", "e4 d5"},
+	{"B01", "Scandinavian Defense: Modern Variation", "e4 d5 exd5 Nf6"},
+	{"B01", "Scandinavian Defense: Main Line", "e4 d5 exd5 Qxd5 Nc3 Qa5"},
+	{"B02", "Alekhine Defense", "e4 Nf6"},
+	{"B03", "Alekhine Defense", "e4 Nf6 e5 Nd5 d4"},
+	{"B04", "Alekhine Defense: Modern Variation", "e4 Nf6 e5 Nd5 d4 d6 Nf3"},
+	{"B06", "Modern Defense", "e4 g6"},
+	{"B07", "Pirc Defense", "e4 d6 d4 Nf6"},
+	{"B08", "Pirc Defense: Classical Variation", "e4 d6 d4 Nf6 Nc3 g6 Nf3"},
+	{"B09", "Pirc Defense: Austrian Attack", "e4 d6 d4 Nf6 Nc3 g6 f4"},
+	{"B10", "Caro-Kann Defense", "e4 c6"},
+	{"B12", "Caro-Kann Defense: Advance Variation", "e4 c6 d4 d5 e5"},
+	{"B13", "Caro-Kann Defense: Exchange Variation", "e4 c6 d4 d5 exd5 cxd5"},
+	{"B15", "Caro-Kann Defense", "e4 c6 d4 d5 Nc3"},
+	{"B17", "Caro-Kann Defense: Karpov Variation", "e4 c6 d4 d5 Nc3 dxe4 Nxe4 Nd7"},
+	{"B18", "Caro-Kann Defense: Classical Variation", "e4 c6 d4 d5 Nc3 dxe4 Nxe4 Bf5"},
+	{"B20", "Sicilian Defense", "e4 c5"},
+	{"B20", "Sicilian Defense: Wing Gambit", "e4 c5 b4"},
+	{"B21", "Sicilian Defense: Smith-Morra Gambit", "e4 c5 d4 cxd4 c3"},
+	{"B22", "Sicilian Defense: Alapin Variation", "e4 c5 c3"},
+	{"B23", "Sicilian Defense: Closed", "e4 c5 Nc3"},
+	{"B27", "Sicilian Defense", "e4 c5 Nf3"},
+	{"B27", "Sicilian Defense: Hyperaccelerated Dragon", "e4 c5 Nf3 g6"},
+	{"B30", "Sicilian Defense: Old Sicilian", "e4 c5 Nf3 Nc6"},
+	{"B30", "Sicilian Defense: Nyezhmetdinov-Rossolimo Attack", "e4 c5 Nf3 Nc6 Bb5"},
+	{"B32", "Sicilian Defense: Open", "e4 c5 Nf3 Nc6 d4 cxd4 Nxd4"},
+	{"B33", "Sicilian Defense: Sveshnikov Variation", "e4 c5 Nf3 Nc6 d4 cxd4 Nxd4 Nf6 Nc3 e5"},
+	{"B34", "Sicilian Defense: Accelerated Dragon", "e4 c5 Nf3 Nc6 d4 cxd4 Nxd4 g6"},
+	{"B40", "Sicilian Defense: French Variation", "e4 c5 Nf3 e6"},
+	{"B42", "Sicilian Defense: Kan Variation", "e4 c5 Nf3 e6 d4 cxd4 Nxd4 a6"},
+	{"B44", "Sicilian Defense: Taimanov Variation", "e4 c5 Nf3 e6 d4 cxd4 Nxd4 Nc6"},
+	{"B50", "Sicilian Defense: Modern Variations", "e4 c5 Nf3 d6"},
+	{"B51", "Sicilian Defense: Moscow Variation", "e4 c5 Nf3 d6 Bb5+"},
+	{"B53", "Sicilian Defense: Modern Variations, Main Line", "e4 c5 Nf3 d6 d4 cxd4 Nxd4"},
+	{"B56", "Sicilian Defense: Classical Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 Nc6"},
+	{"B70", "Sicilian Defense: Dragon Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 g6"},
+	{"B80", "Sicilian Defense: Scheveningen Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 e6"},
+	{"B90", "Sicilian Defense: Najdorf Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 a6"},
+	{"B90", "Sicilian Defense: Najdorf Variation, English Attack", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 a6 Be3"},
+	{"B92", "Sicilian Defense: Najdorf Variation, Opocensky Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 a6 Be2"},
+	{"B94", "Sicilian Defense: Najdorf Variation", "e4 c5 Nf3 d6 d4 cxd4 Nxd4 Nf6 Nc3 a6 Bg5"},
+
+	// C: French Defense and open games
+	{"C00", "French Defense", "e4 e6"},
+	{"C01", "French Defense: Exchange Variation", "e4 e6 d4 d5 exd5"},
+	{"C02", "French Defense: Advance Variation", "e4 e6 d4 d5 e5"},
+	{"C03", "French Defense: Tarrasch Variation", "e4 e6 d4 d5 Nd2"},
+	{"C10", "French Defense: Paulsen Variation", "e4 e6 d4 d5 Nc3"},
+	{"C10", "French Defense: Rubinstein Variation", "e4 e6 d4 d5 Nc3 dxe4"},
+	{"C11", "French Defense: Classical Variation", "e4 e6 d4 d5 Nc3 Nf6"},
+	{"C15", "French Defense: Winawer Variation", "e4 e6 d4 d5 Nc3 Bb4"},
+	{"C20", "King's Pawn Game", "e4 e5"},
+	{"C21", "Center Game", "e4 e5 d4 exd4"},
+	{"C21", "Danish Gambit", "e4 e5 d4 exd4 c3"},
+	{"C22", "Center Game: Accepted", "e4 e5 d4 exd4 Qxd4"},
+	{"C23", "Bishop's Opening", "e4 e5 Bc4"},
+	{"C25", "Vienna Game", "e4 e5 Nc3"},
+	{"C30", "King's Gambit", "e4 e5 f4"},
+	{"C31", "King's Gambit Declined: Falkbeer Countergambit", "e4 e5 f4 d5"},
+	{"C33", "King's Gambit Accepted", "e4 e5 f4 exf4"},
+	{"C40", "King's Knight Opening", "e4 e5 Nf3"},
+	{"C40", "Elephant Gambit", "e4 e5 Nf3 d5"},
+	{"C40", "Latvian Gambit", "e4 e5 Nf3 f5"},
+	{"C41", "Philidor Defense", "e4 e5 Nf3 d6"},
+	{"C42", "Petrov's Defense", "e4 e5 Nf3 Nf6"},
+	{"C44", "King's Knight Opening: Normal Variation", "e4 e5 Nf3 Nc6"},
+	{"C44", "Ponziani Opening", "e4 e5 Nf3 Nc6 c3"},
+	{"C45", "Scotch Game", "e4 e5 Nf3 Nc6 d4"},
+	{"C45", "Scotch Game: Classical Variation", "e4 e5 Nf3 Nc6 d4 exd4 Nxd4 Bc5"},
+	{"C46", "Three Knights Opening", "e4 e5 Nf3 Nc6 Nc3"},
+	{"C47", "Four Knights Game", "e4 e5 Nf3 Nc6 Nc3 Nf6"},
+	{"C47", "Four Knights Game: Scotch Variation", "e4 e5 Nf3 Nc6 Nc3 Nf6 d4"},
+	{"C48", "Four Knights Game: Spanish Variation", "e4 e5 Nf3 Nc6 Nc3 Nf6 Bb5"},
+	{"C50", "Italian Game", "e4 e5 Nf3 Nc6 Bc4"},
+	{"C50", "Italian Game: Giuoco Piano", "e4 e5 Nf3 Nc6 Bc4 Bc5"},
+	{"C50", "Italian Game: Giuoco Pianissimo", "e4 e5 Nf3 Nc6 Bc4 Bc5 d3"},
+	{"C51", "Italian Game: Evans Gambit", "e4 e5 Nf3 Nc6 Bc4 Bc5 b4"},
+	{"C53", "Italian Game: Classical Variation", "e4 e5 Nf3 Nc6 Bc4 Bc5 c3"},
+	{"C55", "Italian Game: Two Knights Defense", "e4 e5 Nf3 Nc6 Bc4 Nf6"},
+	{"C57", "Italian Game: Two Knights Defense, Knight Attack", "e4 e5 Nf3 Nc6 Bc4 Nf6 Ng5"},
+	{"C57", "Italian Game: Two Knights Defense, Traxler Counterattack", "e4 e5 Nf3 Nc6 Bc4 Nf6 Ng5 Bc5"},
+	{"C60", "Ruy Lopez", "e4 e5 Nf3 Nc6 Bb5"},
+	{"C62", "Ruy Lopez: Steinitz Defense", "e4 e5 Nf3 Nc6 Bb5 d6"},
+	{"C64", "Ruy Lopez: Classical Variation", "e4 e5 Nf3 Nc6 Bb5 Bc5"},
+	{"C65", "Ruy Lopez: Berlin Defense", "e4 e5 Nf3 Nc6 Bb5 Nf6"},
+	{"C68", "Ruy Lopez: Exchange Variation", "e4 e5 Nf3 Nc6 Bb5 a6 Bxc6"},
+	{"C70", "Ruy Lopez: Morphy Defense", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4"},
+	{"C78", "Ruy Lopez: Morphy Defense, Castle Variation", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O"},
+	{"C80", "Ruy Lopez: Open", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Nxe4"},
+	{"C84", "Ruy Lopez: Closed", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7"},
+	{"C88", "Ruy Lopez: Closed", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7 Re1 b5 Bb3"},
+	{"C89", "Ruy Lopez: Marshall Attack", "e4 e5 Nf3 Nc6 Bb5 a6 Ba4 Nf6 O-O Be7 Re1 b5 Bb3 O-O c3 d5"},
+
+	// D: closed games and the Grünfeld
+	{"D00", "Queen's Pawn Game", "d4 d5"},
+	{"D00", "Queen's Pawn Game: Accelerated London System", "d4 d5 Bf4"},
+	{"D00", "Blackmar-Diemer Gambit", "d4 d5 e4"},
+	{"D02", "Queen's Pawn Game: Zukertort Variation", "d4 d5 Nf3"},
+	{"D02", "London System", "d4 d5 Nf3 Nf6 Bf4"},
+	{"D06", "Queen's Gambit", "d4 d5 c4"},
+	{"D07", "Queen's Gambit Declined: Chigorin Defense", "d4 d5 c4 Nc6"},
+	{"D08", "Queen's Gambit Declined: Albin Countergambit", "d4 d5 c4 e5"},
+	{"D10", "Slav Defense", "d4 d5 c4 c6"},
+	{"D11", "Slav Defense: Modern Line", "d4 d5 c4 c6 Nf3"},
+	{"D15", "Slav Defense: Three Knights Variation", "d4 d5 c4 c6 Nf3 Nf6 Nc3"},
+	{"D43", "Semi-Slav Defense", "d4 d5 c4 c6 Nf3 Nf6 Nc3 e6"},
+	{"D20", "Queen's Gambit Accepted", "d4 d5 c4 dxc4"},
+	{"D30", "Queen's Gambit Declined", "d4 d5 c4 e6"},
+	{"D31", "Queen's Gambit Declined: Queen's Knight Variation", "d4 d5 c4 e6 Nc3"},
+	{"D32", "Tarrasch Defense", "d4 d5 c4 e6 Nc3 c5"},
+	{"D35", "Queen's Gambit Declined: Exchange Variation", "d4 d5 c4 e6 Nc3 Nf6 cxd5"},
+	{"D37", "Queen's Gambit Declined: Three Knights Variation", "d4 d5 c4 e6 Nc3 Nf6 Nf3"},
+	{"D38", "Queen's Gambit Declined: Ragozin Defense", "d4 d5 c4 e6 Nc3 Nf6 Nf3 Bb4"},
+	{"D50", "Queen's Gambit Declined: Modern Variation", "d4 d5 c4 e6 Nc3 Nf6 Bg5"},
+	{"D80", "Grünfeld Defense", "d4 Nf6 c4 g6 Nc3 d5"},
+	{"D85", "Grünfeld Defense: Exchange Variation", "d4 Nf6 c4 g6 Nc3 d5 cxd5 Nxd5"},
+
+	// E: Indian defences
+	{"E01", "Catalan Opening", "d4 Nf6 c4 e6 g3"},
+	{"E11", "Bogo-Indian Defense", "d4 Nf6 c4 e6 Nf3 Bb4+"},
+	{"E12", "Queen's Indian Defense", "d4 Nf6 c4 e6 Nf3 b6"},
+	{"E20", "Nimzo-Indian Defense", "d4 Nf6 c4 e6 Nc3 Bb4"},
+	{"E32", "Nimzo-Indian Defense: Classical Variation", "d4 Nf6 c4 e6 Nc3 Bb4 Qc2"},
+	{"E40", "Nimzo-Indian Defense: Normal Variation", "d4 Nf6 c4 e6 Nc3 Bb4 e3"},
+	{"E60", "King's Indian Defense", "d4 Nf6 c4 g6"},
+	{"E61", "King's Indian Defense", "d4 Nf6 c4 g6 Nc3 Bg7"},
+	{"E62", "King's Indian Defense: Fianchetto Variation", "d4 Nf6 c4 g6 Nf3 Bg7 g3"},
+	{"E70", "King's Indian Defense: Normal Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6"},
+	{"E76", "King's Indian Defense: Four Pawns Attack", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 f4"},
+	{"E80", "King's Indian Defense: Sämisch Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 f3"},
+	{"E92", "King's Indian Defense: Classical Variation", "d4 Nf6 c4 g6 Nc3 Bg7 e4 d6 Nf3 O-O Be2 e5"},
+}
\ No newline at end of file
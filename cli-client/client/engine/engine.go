@@ -0,0 +1,167 @@
+// FILE: lixenwraith/chess/internal/client/engine/engine.go
+// Package engine implements a small alpha-beta chess engine for offline play.
+package engine
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"chess/internal/client/rules"
+)
+
+const (
+	MateScore = 100000
+	infinity  = MateScore + 1
+
+	MaxLevel          = 20
+	DefaultSearchTime = 1000 * time.Millisecond
+)
+
+// Result is the outcome of a search; Score is in centipawns from the
+// perspective of the side to move
+type Result struct {
+	Move  rules.Move
+	Score int
+	Depth int
+	Nodes int
+}
+
+type searcher struct {
+	deadline time.Time
+	nodes    int
+	stopped  bool
+}
+
+// MaxDepth maps a 0-20 strength level to a search depth limit
+func MaxDepth(level int) int {
+	level = max(0, min(level, MaxLevel))
+	return 1 + level/4
+}
+
+// Search finds the best move by iterative deepening within the level's depth
+// limit and the time budget. Lower levels add noise to root move scores.
+// ok is false when the side to move has no legal moves
+func Search(p *rules.Position, level int, searchTime time.Duration) (res Result, ok bool) {
+	if searchTime <= 0 {
+		searchTime = DefaultSearchTime
+	}
+	level = max(0, min(level, MaxLevel))
+
+	root := p.LegalMoves()
+	if len(root) == 0 {
+		return Result{}, false
+	}
+	orderMoves(p, root)
+
+	s := &searcher{deadline: time.Now().Add(searchTime)}
+	noise := (MaxLevel - level) * 8
+
+	res = Result{Move: root[0]}
+	for depth := 1; depth <= MaxDepth(level); depth++ {
+		best, bestScore := root[0], -infinity
+		scores := make(map[rules.Move]int, len(root))
+		for _, m := range root {
+			score := -s.negamax(p.Apply(m), depth-1, 1, -infinity, infinity)
+			if s.stopped {
+				break
+			}
+			if noise > 0 && score > -MateScore/2 && score < MateScore/2 {
+				score += rand.Intn(2*noise+1) - noise
+			}
+			scores[m] = score
+			if score > bestScore {
+				best, bestScore = m, score
+			}
+		}
+		if s.stopped {
+			break
+		}
+
+		res = Result{Move: best, Score: bestScore, Depth: depth, Nodes: s.nodes}
+		if bestScore >= MateScore-depth {
+			break
+		}
+		// Search the previous best line first on the next iteration
+		sort.SliceStable(root, func(i, j int) bool { return scores[root[i]] > scores[root[j]] })
+	}
+	res.Nodes = s.nodes
+	return res, true
+}
+
+func (s *searcher) negamax(p *rules.Position, depth, ply, alpha, beta int) int {
+	s.nodes++
+	if s.nodes&1023 == 0 && time.Now().After(s.deadline) {
+		s.stopped = true
+	}
+	if s.stopped {
+		return 0
+	}
+
+	if depth <= 0 {
+		return s.quiesce(p, alpha, beta)
+	}
+
+	moves := p.LegalMoves()
+	if len(moves) == 0 {
+		if p.InCheck() {
+			return -MateScore + ply
+		}
+		return 0
+	}
+	if p.Halfmove >= 100 || p.InsufficientMaterial() {
+		return 0
+	}
+
+	orderMoves(p, moves)
+	for _, m := range moves {
+		score := -s.negamax(p.Apply(m), depth-1, ply+1, -beta, -alpha)
+		if score >= beta {
+			return beta
+		}
+		if score > alpha {
+			alpha = score
+		}
+	}
+	return alpha
+}
+
+func (s *searcher) quiesce(p *rules.Position, alpha, beta int) int {
+	s.nodes++
+	standPat := Evaluate(p)
+	if standPat >= beta {
+		return beta
+	}
+	if standPat > alpha {
+		alpha = standPat
+	}
+
+	moves := p.LegalCaptures()
+	orderMoves(p, moves)
+	for _, m := range moves {
+		score := -s.quiesce(p.Apply(m), -beta, -alpha)
+		if score >= beta {
+			return beta
+		}
+		if score > alpha {
+			alpha = score
+		}
+	}
+	return alpha
+}
+
+// orderMoves sorts captures by most valuable victim, least valuable attacker
+// ahead of quiet moves
+func orderMoves(p *rules.Position, moves []rules.Move) {
+	key := func(m rules.Move) int {
+		k := 0
+		if victim := p.Board[m.To]; victim != 0 {
+			k = 10*pieceValue[rules.Kind(victim)] - pieceValue[rules.Kind(p.Board[m.From])]
+		}
+		if m.Promo != 0 {
+			k += pieceValue[m.Promo]
+		}
+		return k
+	}
+	sort.SliceStable(moves, func(i, j int) bool { return key(moves[i]) > key(moves[j]) })
+}
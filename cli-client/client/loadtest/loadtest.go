@@ -0,0 +1,327 @@
+// FILE: lixenwraith/chess/internal/client/loadtest/loadtest.go
+// Package loadtest drives many simultaneous games against a chess server and
+// measures how it holds up.
+package loadtest
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	mrand "math/rand"
+	"sync"
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/rules"
+)
+
+// maxPlies ends a game that has not finished on its own
+const maxPlies = 200
+
+// MaxRate is the highest move rate a run can be throttled to, one move slot
+// per microsecond
+const MaxRate = 1e6
+
+// Config describes a load test run
+type Config struct {
+	BaseURL    string
+	Players    int           // concurrent virtual players
+	Duration   time.Duration // how long to keep starting moves
+	Rate       float64       // total moves per second across players up to MaxRate, 0 for unthrottled
+	Computer   float64       // fraction of games played against the server engine
+	Register   bool          // register and log in a user per player
+	Level      int           // engine level for computer games
+	SearchTime int           // engine search time in milliseconds
+}
+
+// Report summarizes a finished run
+type Report struct {
+	Elapsed       time.Duration
+	Players       int
+	GamesCreated  int
+	GamesFinished int
+	HumanMoves    int
+	EngineMoves   int
+	Routes        []api.RouteStats
+	Failures      map[string]int // client-side failures by message
+}
+
+// Requests returns the total number of API calls made
+func (r *Report) Requests() int {
+	n := 0
+	for _, rs := range r.Routes {
+		n += rs.Count
+	}
+	return n
+}
+
+// Errors returns the number of failed API calls
+func (r *Report) Errors() int {
+	n := 0
+	for _, rs := range r.Routes {
+		n += rs.Errors
+	}
+	return n
+}
+
+// Progress is reported periodically while a run is in flight
+type Progress struct {
+	Elapsed time.Duration
+	Moves   int
+	Games   int
+}
+
+type counters struct {
+	mu            sync.Mutex
+	gamesCreated  int
+	gamesFinished int
+	humanMoves    int
+	engineMoves   int
+	failures      map[string]int
+}
+
+func (c *counters) add(f func(c *counters)) {
+	c.mu.Lock()
+	f(c)
+	c.mu.Unlock()
+}
+
+func (c *counters) fail(err error) {
+	c.add(func(c *counters) { c.failures[err.Error()]++ })
+}
+
+// Run executes the load test, calling progress (if non-nil) every interval
+func Run(ctx context.Context, cfg Config, interval time.Duration, progress func(Progress)) *Report {
+	if cfg.Players < 1 {
+		cfg.Players = 1
+	}
+	ctx, cancel := context.WithTimeout(ctx, cfg.Duration)
+	defer cancel()
+
+	// A shared ticker hands out move slots to whichever player is ready
+	var slots <-chan time.Time
+	if cfg.Rate > 0 {
+		ticker := time.NewTicker(slotInterval(cfg.Rate, cfg.Duration))
+		defer ticker.Stop()
+		slots = ticker.C
+	}
+
+	stats := &counters{failures: make(map[string]int)}
+	clients := make([]*api.Client, cfg.Players)
+	runID := newRunID()
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i := range clients {
+		c := api.New(cfg.BaseURL)
+		c.SetQuiet(true)
+		clients[i] = c
+		p := &player{
+			id:     fmt.Sprintf("loadtest-%s-%d", runID, i),
+			client: c,
+			cfg:    cfg,
+			stats:  stats,
+			slots:  slots,
+			rng:    mrand.New(mrand.NewSource(time.Now().UnixNano() + int64(i))),
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.run(ctx)
+		}()
+	}
+
+	if progress != nil && interval > 0 {
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					stats.mu.Lock()
+					pr := Progress{
+						Elapsed: time.Since(start),
+						Moves:   stats.humanMoves + stats.engineMoves,
+						Games:   stats.gamesCreated,
+					}
+					stats.mu.Unlock()
+					progress(pr)
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	sets := make([][]api.RouteStats, len(clients))
+	for i, c := range clients {
+		sets[i] = c.Stats()
+	}
+	return &Report{
+		Elapsed:       time.Since(start),
+		Players:       cfg.Players,
+		GamesCreated:  stats.gamesCreated,
+		GamesFinished: stats.gamesFinished,
+		HumanMoves:    stats.humanMoves,
+		EngineMoves:   stats.engineMoves,
+		Routes:        api.MergeStats(sets...),
+		Failures:      stats.failures,
+	}
+}
+
+// slotInterval converts a move rate to the ticker period, clamped to between
+// a microsecond and the whole run so tiny or huge rates neither overflow nor
+// reach zero
+func slotInterval(rate float64, run time.Duration) time.Duration {
+	if rate >= MaxRate {
+		return time.Microsecond
+	}
+	interval := float64(time.Second) / rate
+	switch {
+	case interval < float64(run):
+		return time.Duration(interval)
+	case run > time.Microsecond:
+		return run
+	}
+	return time.Microsecond
+}
+
+type player struct {
+	id     string
+	client *api.Client
+	cfg    Config
+	stats  *counters
+	slots  <-chan time.Time
+	rng    *mrand.Rand
+}
+
+func (p *player) run(ctx context.Context) {
+	if p.cfg.Register {
+		if err := p.login(); err != nil {
+			p.stats.fail(err)
+			return
+		}
+	}
+
+	for ctx.Err() == nil {
+		if err := p.playGame(ctx); err != nil {
+			p.stats.fail(err)
+			// Back off briefly so a failing server is not hammered in a tight loop
+			select {
+			case <-ctx.Done():
+			case <-time.After(100 * time.Millisecond):
+			}
+		}
+	}
+}
+
+func (p *player) login() error {
+	password := newRunID()
+	if _, err := p.client.Register(p.id, password, ""); err != nil {
+		return fmt.Errorf("register: %w", err)
+	}
+	auth, err := p.client.Login(p.id, password)
+	if err != nil {
+		return fmt.Errorf("login: %w", err)
+	}
+	p.client.SetToken(auth.Token)
+	return nil
+}
+
+func (p *player) playGame(ctx context.Context) error {
+	req := &api.CreateGameRequest{
+		White: api.PlayerConfig{Type: api.Human},
+		Black: api.PlayerConfig{Type: api.Human},
+	}
+	if p.rng.Float64() < p.cfg.Computer {
+		req.Black = api.PlayerConfig{Type: api.Computer, Level: p.cfg.Level, SearchTime: p.cfg.SearchTime}
+	}
+
+	game, err := p.client.CreateGame(req)
+	if err != nil {
+		return fmt.Errorf("create game: %w", err)
+	}
+	p.stats.add(func(c *counters) { c.gamesCreated++ })
+	defer p.client.DeleteGame(game.GameID)
+
+	for ctx.Err() == nil {
+		if game.State != api.StateOngoing || len(game.Moves) >= maxPlies {
+			p.stats.add(func(c *counters) { c.gamesFinished++ })
+			return nil
+		}
+
+		if !p.waitSlot(ctx) {
+			return nil
+		}
+
+		if game.Players.Player(game.Turn).Type == api.Computer {
+			game, err = p.engineMove(ctx, game)
+			if err != nil {
+				return err
+			}
+			// A move still pending when the run ends never completed
+			if game.State == api.StatePending {
+				return nil
+			}
+			p.stats.add(func(c *counters) { c.engineMoves++ })
+			continue
+		}
+
+		pos, err := rules.ParseFEN(game.FEN)
+		if err != nil {
+			return fmt.Errorf("server FEN: %w", err)
+		}
+		moves := pos.LegalMoves()
+		if len(moves) == 0 {
+			return fmt.Errorf("no legal moves in ongoing game")
+		}
+		game, err = p.client.MakeMove(game.GameID, moves[p.rng.Intn(len(moves))].String())
+		if err != nil {
+			return fmt.Errorf("move: %w", err)
+		}
+		p.stats.add(func(c *counters) { c.humanMoves++ })
+	}
+	return nil
+}
+
+// engineMove triggers the server engine and polls until the move lands, or
+// returns the still pending game once ctx is done
+func (p *player) engineMove(ctx context.Context, game *api.GameResponse) (*api.GameResponse, error) {
+	resp, err := p.client.MakeMove(game.GameID, "cccc")
+	if err != nil {
+		return nil, fmt.Errorf("engine move: %w", err)
+	}
+	for resp.State == api.StatePending {
+		select {
+		case <-ctx.Done():
+			return resp, nil
+		case <-time.After(200 * time.Millisecond):
+		}
+		if resp, err = p.client.GetGame(game.GameID); err != nil {
+			return nil, fmt.Errorf("poll engine move: %w", err)
+		}
+	}
+	return resp, nil
+}
+
+func (p *player) waitSlot(ctx context.Context) bool {
+	if p.slots == nil {
+		return ctx.Err() == nil
+	}
+	select {
+	case <-p.slots:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func newRunID() string {
+	var b [4]byte
+	rand.Read(b[:])
+	return fmt.Sprintf("%x", b)
+}
@@ -0,0 +1,49 @@
+// FILE: lixenwraith/chess/internal/client/loadtest/loadtest_test.go
+package loadtest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"chess/internal/client/clienttest"
+	"chess/internal/client/loadtest"
+)
+
+func TestPendingEngineMoveNotCounted(t *testing.T) {
+	fake, srv := clienttest.NewServer()
+	defer srv.Close()
+	fake.SetComputerDelay(time.Second)
+
+	report := loadtest.Run(context.Background(), loadtest.Config{
+		BaseURL:  srv.URL,
+		Players:  1,
+		Duration: 300 * time.Millisecond,
+		Computer: 1,
+	}, 0, nil)
+
+	if report.HumanMoves != 1 || report.EngineMoves != 0 {
+		t.Fatalf("human %d, engine %d moves; want the pending engine move left out", report.HumanMoves, report.EngineMoves)
+	}
+	if report.GamesFinished != 0 {
+		t.Fatalf("games finished = %d, want 0", report.GamesFinished)
+	}
+}
+
+func TestExtremeRates(t *testing.T) {
+	fake, srv := clienttest.NewServer()
+	defer srv.Close()
+	fake.SetComputerDelay(0)
+
+	for _, rate := range []float64{1e-13, loadtest.MaxRate} {
+		report := loadtest.Run(context.Background(), loadtest.Config{
+			BaseURL:  srv.URL,
+			Players:  1,
+			Duration: 100 * time.Millisecond,
+			Rate:     rate,
+		}, 0, nil)
+		if len(report.Failures) != 0 {
+			t.Fatalf("rate %g: failures %v", rate, report.Failures)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,359 @@
+// FILE: lixenwraith/chess/internal/client/local/client.go
+// Package local hosts games in-process with the embedded engine behind the
+// session.Backend interface so commands work without a server.
+package local
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/engine"
+	"chess/internal/client/rules"
+	"chess/internal/client/session"
+)
+
+const PollTimeout = 25 * time.Second
+
+// drawThinkTime is how long a computer considers a draw offer
+const drawThinkTime = 200 * time.Millisecond
+
+var (
+	_ session.Backend = (*Client)(nil)
+	_ session.Lister  = (*Client)(nil)
+)
+
+// Client is an offline game backend, safe for concurrent use
+type Client struct {
+	mu    sync.Mutex
+	games map[string]*game
+}
+
+func New() *Client {
+	return &Client{games: make(map[string]*game)}
+}
+
+func (c *Client) Health() (*api.HealthResponse, error) {
+	return &api.HealthResponse{
+		Status:  "healthy",
+		Time:    time.Now().Unix(),
+		Storage: "local",
+	}, nil
+}
+
+func (c *Client) CreateGame(req *api.CreateGameRequest) (*api.GameResponse, error) {
+	for _, p := range []api.PlayerConfig{req.White, req.Black} {
+		if !p.Type.Valid() {
+			return nil, fmt.Errorf("invalid player type: %d", p.Type)
+		}
+	}
+
+	fen := req.FEN
+	if fen == "" {
+		fen = rules.StartFEN
+	}
+	// Reject what the server rejects, in the same form
+	pos, err := rules.CheckFEN(fen)
+	if err != nil {
+		return nil, &api.APIError{
+			Status:   http.StatusBadRequest,
+			Response: &api.ErrorResponse{Error: err.Error(), Code: "INVALID_FEN"},
+		}
+	}
+
+	g := &game{
+		id:      newID(),
+		history: rules.NewHistory(pos),
+		white:   newPlayer(req.White),
+		black:   newPlayer(req.Black),
+		created: time.Now(),
+		changed: make(chan struct{}),
+	}
+	g.updated = g.created
+	g.updateState()
+
+	c.mu.Lock()
+	c.games[g.id] = g
+	c.mu.Unlock()
+
+	return g.response(), nil
+}
+
+// ListGames lists the games hosted in this process. There are no accounts
+// offline, so the Mine filter matches every game
+func (c *Client) ListGames(q *api.ListGamesQuery) (*api.GameListResponse, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	games := make([]api.GameSummary, 0, len(c.games))
+	for _, g := range c.games {
+		games = append(games, g.summary())
+	}
+	c.mu.Unlock()
+
+	all := *q
+	all.Mine = false
+	return api.PageGames(games, &all, ""), nil
+}
+
+func (c *Client) GetGame(gameID string) (*api.GameResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	g, ok := c.games[gameID]
+	if !ok {
+		return nil, fmt.Errorf("game not found: %s", gameID)
+	}
+	return g.response(), nil
+}
+
+// GetGameWithPoll waits until the game has more than moveCount moves or the
+// poll times out
+func (c *Client) GetGameWithPoll(gameID string, moveCount int) (*api.GameResponse, error) {
+	timeout := time.After(PollTimeout)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for {
+		g, ok := c.games[gameID]
+		if !ok {
+			return nil, fmt.Errorf("game not found: %s", gameID)
+		}
+		if len(g.moves) > moveCount {
+			return g.response(), nil
+		}
+
+		changed := g.changed
+		c.mu.Unlock()
+		select {
+		case <-changed:
+			c.mu.Lock()
+		case <-timeout:
+			c.mu.Lock()
+			return g.response(), nil
+		}
+	}
+}
+
+func (c *Client) DeleteGame(gameID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	g, ok := c.games[gameID]
+	if !ok {
+		return fmt.Errorf("game not found: %s", gameID)
+	}
+	delete(c.games, gameID)
+	g.notify()
+	return nil
+}
+
+// MakeMove plays a UCI move, or starts an engine search for "cccc" and
+// returns the game in "pending" state until the search completes
+func (c *Client) MakeMove(gameID string, move string) (*api.GameResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	g, ok := c.games[gameID]
+	if !ok {
+		return nil, fmt.Errorf("game not found: %s", gameID)
+	}
+	switch g.state {
+	case api.StateOngoing:
+	case api.StatePending:
+		return nil, fmt.Errorf("computer move in progress")
+	default:
+		return nil, fmt.Errorf("game is over: %s", g.state)
+	}
+
+	mover := g.playerToMove()
+	pos := g.position()
+
+	if move == "cccc" {
+		if mover.Type != api.Computer {
+			return nil, fmt.Errorf("side to move is not a computer")
+		}
+		g.state = api.StatePending
+		go c.think(g, pos, mover)
+		return g.response(), nil
+	}
+
+	if mover.Type != api.Human {
+		return nil, fmt.Errorf("side to move is a computer, use 'cccc' to trigger it")
+	}
+	m, err := pos.ParseMove(move)
+	if err != nil {
+		return nil, err
+	}
+	g.play(m, nil)
+	return g.response(), nil
+}
+
+func (c *Client) think(g *game, pos *rules.Position, mover api.PlayerInfo) {
+	res, _ := engine.Search(pos, mover.Level, time.Duration(mover.SearchTime)*time.Millisecond)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Discard the result if the game was deleted or undone meanwhile
+	if c.games[g.id] != g || g.state != api.StatePending || g.position() != pos {
+		return
+	}
+	g.state = api.StateOngoing
+	g.play(res.Move, &res)
+}
+
+func (c *Client) UndoMoves(gameID string, count int) (*api.GameResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	g, ok := c.games[gameID]
+	if !ok {
+		return nil, fmt.Errorf("game not found: %s", gameID)
+	}
+	if count < 1 || count > len(g.moves) {
+		return nil, fmt.Errorf("cannot undo %d of %d moves", count, len(g.moves))
+	}
+	g.undo(count)
+	return g.response(), nil
+}
+
+// ongoingGame looks up a game that can still be ended; callers hold c.mu
+func (c *Client) ongoingGame(gameID string) (*game, error) {
+	g, ok := c.games[gameID]
+	if !ok {
+		return nil, fmt.Errorf("game not found: %s", gameID)
+	}
+	switch g.state {
+	case api.StateOngoing:
+		return g, nil
+	case api.StatePending:
+		return nil, fmt.Errorf("computer move in progress")
+	}
+	return nil, fmt.Errorf("game is over: %s", g.state)
+}
+
+// Resign ends the game as a loss for color, which must be a human side
+func (c *Client) Resign(gameID string, color api.Color) (*api.GameResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	g, err := c.ongoingGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	if !color.Valid() || g.player(color).Type != api.Human {
+		return nil, fmt.Errorf("only a human side can resign")
+	}
+	winner := api.StateWhiteWins
+	if color == api.White {
+		winner = api.StateBlackWins
+	}
+	g.end(winner, api.TermResignation)
+	return g.response(), nil
+}
+
+// Draw handles draw offers, answers and claims. A computer opponent
+// answers an offer at once, taking the draw unless its engine thinks it is
+// better; the game stays usable while it thinks
+func (c *Client) Draw(gameID string, req *api.DrawRequest) (*api.GameResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	g, err := c.ongoingGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	if !req.Color.Valid() || g.player(req.Color).Type != api.Human {
+		return nil, fmt.Errorf("only a human side can act on draws")
+	}
+
+	switch req.Action {
+	case api.DrawOffer:
+		opponent := g.player(req.Color.Other())
+		if opponent.Type != api.Computer {
+			g.drawOffer = req.Color
+			g.notify()
+			break
+		}
+		// Think without holding the lock, then make sure the game is still
+		// where the offer was made before answering it
+		pos := g.position()
+		c.mu.Unlock()
+		res, ok := engine.Search(pos, opponent.Level, drawThinkTime)
+		c.mu.Lock()
+		if c.games[gameID] != g || g.state != api.StateOngoing || g.position() != pos {
+			return nil, fmt.Errorf("game changed while the draw offer was considered")
+		}
+		score := res.Score
+		if api.Color(pos.Turn) == req.Color {
+			score = -score
+		}
+		if !ok || score <= 0 {
+			g.end(api.StateDraw, api.TermAgreement)
+		}
+
+	case api.DrawAccept, api.DrawDecline:
+		if g.drawOffer != req.Color.Other() {
+			return nil, fmt.Errorf("opponent has not offered a draw")
+		}
+		if req.Action == api.DrawAccept {
+			g.end(api.StateDraw, api.TermAgreement)
+		} else {
+			g.drawOffer = api.NoColor
+			g.notify()
+		}
+
+	case api.DrawClaim:
+		switch req.Reason {
+		case api.TermFiftyMoves:
+			if g.position().Halfmove < rules.ClaimPlies {
+				return nil, fmt.Errorf("no draw by %s", req.Reason)
+			}
+		case api.TermRepetition:
+			if g.history.Repetitions() < 3 {
+				return nil, fmt.Errorf("no draw by %s", req.Reason)
+			}
+		default:
+			return nil, fmt.Errorf("unknown claim reason %q", req.Reason)
+		}
+		g.end(api.StateDraw, req.Reason)
+
+	default:
+		return nil, fmt.Errorf("unknown draw action %q", req.Action)
+	}
+	return g.response(), nil
+}
+
+func (c *Client) GetBoard(gameID string) (*api.BoardResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	g, ok := c.games[gameID]
+	if !ok {
+		return nil, fmt.Errorf("game not found: %s", gameID)
+	}
+	pos := g.position()
+	return &api.BoardResponse{FEN: pos.FEN(), Board: pos.ASCII()}, nil
+}
+
+var errOffline = fmt.Errorf("accounts are not available offline")
+
+func (c *Client) Register(username, password, email string) (*api.AuthResponse, error) {
+	return nil, errOffline
+}
+
+func (c *Client) Login(identifier, password string) (*api.AuthResponse, error) {
+	return nil, errOffline
+}
+
+func (c *Client) GetCurrentUser() (*api.UserResponse, error) {
+	return nil, errOffline
+}
+
+// SetToken is a no-op; offline games have no owners
+func (c *Client) SetToken(token string) {}
+
+func newID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
\ No newline at end of file
@@ -0,0 +1,152 @@
+// FILE: lixenwraith/chess/internal/client/local/game.go
+package local
+
+import (
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/engine"
+	"chess/internal/client/rules"
+)
+
+type game struct {
+	id       string
+	history  *rules.History // game from its start position
+	moves    []string
+	state    api.GameState
+	white    api.PlayerInfo
+	black    api.PlayerInfo
+	lastMove *api.MoveInfo
+	created  time.Time
+	updated  time.Time
+	changed  chan struct{} // closed and replaced on every change
+
+	drawOffer   api.Color // side with a standing draw offer
+	termination api.Termination
+}
+
+func newPlayer(cfg api.PlayerConfig) api.PlayerInfo {
+	p := api.PlayerInfo{
+		ID:         newID(),
+		Type:       cfg.Type,
+		Level:      cfg.Level,
+		SearchTime: cfg.SearchTime,
+	}
+	if p.Type == api.Computer && p.SearchTime == 0 {
+		p.SearchTime = int(engine.DefaultSearchTime.Milliseconds())
+	}
+	return p
+}
+
+func (g *game) position() *rules.Position {
+	return g.history.Position()
+}
+
+func (g *game) playerToMove() api.PlayerInfo {
+	return g.player(api.Color(g.position().Turn))
+}
+
+func (g *game) player(c api.Color) api.PlayerInfo {
+	if c == api.Black {
+		return g.black
+	}
+	return g.white
+}
+
+// play applies a legal move; res carries engine details for computer moves
+func (g *game) play(m rules.Move, res *engine.Result) {
+	pos := g.position()
+	g.history.Play(m)
+	g.moves = append(g.moves, m.String())
+	g.lastMove = &api.MoveInfo{Move: m.String(), PlayerColor: api.Color(pos.Turn)}
+	if res != nil {
+		g.lastMove.Score = res.Score
+		g.lastMove.Depth = res.Depth
+	}
+	// Moving instead of answering declines the opponent's draw offer
+	if g.drawOffer != api.Color(pos.Turn) {
+		g.drawOffer = api.NoColor
+	}
+	g.updateState()
+	g.notify()
+}
+
+func (g *game) undo(count int) {
+	g.history.Undo(count)
+	g.moves = g.moves[:len(g.moves)-count]
+	g.lastMove = nil
+	g.drawOffer = api.NoColor
+	g.updateState()
+	g.notify()
+}
+
+// end finishes the game off the board
+func (g *game) end(state api.GameState, termination api.Termination) {
+	g.state = state
+	g.termination = termination
+	g.drawOffer = api.NoColor
+	g.notify()
+}
+
+// updateState ends the game by the rules that apply without a claim;
+// threefold repetition and the fifty-move rule wait for one
+func (g *game) updateState() {
+	pos := g.position()
+	g.termination = ""
+	switch pos.Status() {
+	case rules.Checkmate:
+		g.state = api.StateWhiteWins
+		if pos.Turn == rules.White {
+			g.state = api.StateBlackWins
+		}
+	case rules.Stalemate:
+		g.state = api.StateStalemate
+	case rules.InsufficientMaterial:
+		g.state, g.termination = api.StateDraw, api.TermInsufficient
+	case rules.SeventyFiveMoves:
+		g.state, g.termination = api.StateDraw, api.TermSeventyFive
+	default:
+		g.state = api.StateOngoing
+		if g.history.Repetitions() >= rules.AutoDrawRepetitions {
+			g.state, g.termination = api.StateDraw, api.TermFivefold
+		}
+	}
+}
+
+func (g *game) notify() {
+	g.updated = time.Now()
+	close(g.changed)
+	g.changed = make(chan struct{})
+}
+
+func (g *game) summary() api.GameSummary {
+	return api.GameSummary{
+		GameID:    g.id,
+		Turn:      api.Color(g.position().Turn),
+		State:     g.state,
+		MoveCount: len(g.moves),
+		Players:   api.PlayersResponse{White: g.white, Black: g.black},
+		CreatedAt: g.created,
+		UpdatedAt: g.updated,
+	}
+}
+
+func (g *game) response() *api.GameResponse {
+	pos := g.position()
+	resp := &api.GameResponse{
+		GameID:  g.id,
+		FEN:     pos.FEN(),
+		Turn:    api.Color(pos.Turn),
+		State:   g.state,
+		Moves:   append([]string{}, g.moves...),
+		Players: api.PlayersResponse{White: g.white, Black: g.black},
+
+		DrawOffer:   g.drawOffer,
+		Termination: g.termination,
+	}
+	if g.lastMove != nil {
+		lm := *g.lastMove
+		resp.LastMove = &lm
+	}
+	return resp
+}
\ No newline at end of file
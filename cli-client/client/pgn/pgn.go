@@ -0,0 +1,146 @@
+// FILE: lixenwraith/chess/internal/client/pgn/pgn.go
+// Package pgn writes games in Portable Game Notation.
+package pgn
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// lineWidth is the export format's maximum line length
+const lineWidth = 80
+
+// Tag is one header pair
+type Tag struct {
+	Name  string
+	Value string
+}
+
+// Move is one ply in SAN with optional annotations
+type Move struct {
+	SAN     string
+	Suffix  string // move assessment such as !, ?, ?? or ?!
+	Comment string
+}
+
+// Game is a game ready for export
+type Game struct {
+	Tags     []Tag  // written in order, the seven tag roster first
+	StartFEN string // "" for the standard start position
+	Moves    []Move
+}
+
+// NewGame returns a game with the seven tag roster set to unknown values
+func NewGame() *Game {
+	return &Game{Tags: []Tag{
+		{"Event", "?"},
+		{"Site", "?"},
+		{"Date", "????.??.??"},
+		{"Round", "?"},
+		{"White", "?"},
+		{"Black", "?"},
+		{"Result", "*"},
+	}}
+}
+
+// SetTag replaces the value of a tag or appends it
+func (g *Game) SetTag(name, value string) {
+	for i := range g.Tags {
+		if g.Tags[i].Name == name {
+			g.Tags[i].Value = value
+			return
+		}
+	}
+	g.Tags = append(g.Tags, Tag{name, value})
+}
+
+// GetTag returns the value of a tag, "" if unset
+func (g *Game) GetTag(name string) string {
+	for _, t := range g.Tags {
+		if t.Name == name {
+			return t.Value
+		}
+	}
+	return ""
+}
+
+// Write renders the game in export format
+func (g *Game) Write(w io.Writer) error {
+	var b strings.Builder
+	if g.StartFEN != "" {
+		g.SetTag("SetUp", "1")
+		g.SetTag("FEN", g.StartFEN)
+	}
+	for _, t := range g.Tags {
+		fmt.Fprintf(&b, "[%s \"%s\"]\n", t.Name, escapeTag(t.Value))
+	}
+	b.WriteString("\n")
+
+	result := g.GetTag("Result")
+	if result == "" {
+		result = "*"
+	}
+	writeWrapped(&b, append(g.movetext(), result))
+	b.WriteString("\n\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// movetext returns the move tokens with numbers and comments
+func (g *Game) movetext() []string {
+	number, black := 1, false
+	if fields := strings.Fields(g.StartFEN); len(fields) == 6 {
+		black = fields[1] == "b"
+		if n, err := strconv.Atoi(fields[5]); err == nil && n > 0 {
+			number = n
+		}
+	}
+
+	var tokens []string
+	resume := true // the next black move needs its number repeated
+	for _, m := range g.Moves {
+		switch {
+		case !black:
+			tokens = append(tokens, fmt.Sprintf("%d.", number))
+		case resume:
+			tokens = append(tokens, fmt.Sprintf("%d...", number))
+		}
+		tokens = append(tokens, m.SAN+m.Suffix)
+		resume = false
+		if m.Comment != "" {
+			tokens = append(tokens, "{ "+strings.ReplaceAll(m.Comment, "}", ")")+" }")
+			resume = true
+		}
+		if black {
+			number++
+		}
+		black = !black
+	}
+	return tokens
+}
+
+// writeWrapped joins tokens with spaces, breaking lines before lineWidth.
+// Comments are split on their spaces so long ones wrap as well
+func writeWrapped(b *strings.Builder, tokens []string) {
+	col := 0
+	for _, token := range tokens {
+		for _, word := range strings.Fields(token) {
+			if col > 0 && col+1+len(word) > lineWidth {
+				b.WriteString("\n")
+				col = 0
+			} else if col > 0 {
+				b.WriteString(" ")
+				col++
+			}
+			b.WriteString(word)
+			col += len(word)
+		}
+	}
+}
+
+func escapeTag(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
\ No newline at end of file
@@ -0,0 +1,214 @@
+// FILE: lixenwraith/chess/internal/client/rules/fen.go
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FEN field numbers as used in FENError
+const (
+	FieldPlacement = iota + 1
+	FieldTurn
+	FieldCastling
+	FieldEnPassant
+	FieldHalfmove
+	FieldFullmove
+)
+
+var fieldNames = [...]string{"", "piece placement", "side to move", "castling", "en passant", "halfmove clock", "fullmove number"}
+
+// FENError is one problem found in a FEN, located by field and, within the
+// piece placement, by rank
+type FENError struct {
+	Field int // 1-6, 0 when the problem concerns the FEN as a whole
+	Rank  int // 1-8 for problems inside one rank of the placement, else 0
+	Msg   string
+}
+
+func (e *FENError) Error() string {
+	switch {
+	case e.Field == 0:
+		return e.Msg
+	case e.Rank > 0:
+		return fmt.Sprintf("field %d (%s), rank %d: %s", e.Field, fieldNames[e.Field], e.Rank, e.Msg)
+	}
+	return fmt.Sprintf("field %d (%s): %s", e.Field, fieldNames[e.Field], e.Msg)
+}
+
+// FENErrors collects every problem found in a FEN
+type FENErrors []*FENError
+
+func (e FENErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *FENErrors) add(field, rank int, format string, args ...any) {
+	*e = append(*e, &FENError{Field: field, Rank: rank, Msg: fmt.Sprintf(format, args...)})
+}
+
+// err returns the collected problems as an error, nil when there are none
+func (e FENErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// ParseFEN parses a FEN string into a position. All syntax problems are
+// reported together as FENErrors; whether the position is legal is left to
+// Validate
+func ParseFEN(fen string) (*Position, error) {
+	var errs FENErrors
+	fields := strings.Fields(fen)
+	for i := len(fields); i < 6; i++ {
+		errs.add(i+1, 0, "missing")
+	}
+	if len(fields) > 6 {
+		errs.add(0, 0, "unexpected text after the fullmove number: %q", strings.Join(fields[6:], " "))
+	}
+	field := func(n int) (string, bool) {
+		if n > len(fields) {
+			return "", false
+		}
+		return fields[n-1], true
+	}
+
+	p := &Position{EnPassant: NoSquare}
+	if s, ok := field(FieldPlacement); ok {
+		p.parsePlacement(s, &errs)
+	}
+
+	if s, ok := field(FieldTurn); ok {
+		switch s {
+		case "w":
+			p.Turn = White
+		case "b":
+			p.Turn = Black
+		default:
+			errs.add(FieldTurn, 0, "%q is not w or b", s)
+		}
+	}
+
+	if s, ok := field(FieldCastling); ok {
+		castling, err := ParseCastling(s)
+		if err != nil {
+			errs.add(FieldCastling, 0, "%s", err.Error())
+		}
+		p.Castling = castling
+	}
+
+	if s, ok := field(FieldEnPassant); ok && s != "-" {
+		sq, ok := ParseSquare(s)
+		if !ok {
+			errs.add(FieldEnPassant, 0, "%q is not a square or -", s)
+		}
+		p.EnPassant = sq
+	}
+
+	if s, ok := field(FieldHalfmove); ok {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			errs.add(FieldHalfmove, 0, "%q is not a non-negative number", s)
+		}
+		p.Halfmove = n
+	}
+
+	if s, ok := field(FieldFullmove); ok {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			errs.add(FieldFullmove, 0, "%q is not a positive number", s)
+		}
+		p.Fullmove = n
+	}
+
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// CheckFEN parses a FEN and validates that the position is legal, reporting
+// every problem found
+func CheckFEN(fen string) (*Position, error) {
+	p, err := ParseFEN(fen)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Position) parsePlacement(s string, errs *FENErrors) {
+	ranks := strings.Split(s, "/")
+	if len(ranks) != 8 {
+		errs.add(FieldPlacement, 0, "%d ranks separated by '/', want 8", len(ranks))
+	}
+	for i, rank := range ranks {
+		if i > 7 {
+			break
+		}
+		r := 8 - i
+		file, digit := 0, false
+		for _, c := range rank {
+			switch {
+			case c >= '1' && c <= '8':
+				if digit {
+					errs.add(FieldPlacement, r, "consecutive digits, empty squares must be written as one digit")
+				}
+				file += int(c - '0')
+				digit = true
+				continue
+			case strings.ContainsRune("pnbrqkPNBRQK", c):
+				if file < 8 {
+					p.Board[(7-i)*8+file] = byte(c)
+				}
+			default:
+				errs.add(FieldPlacement, r, "invalid piece letter %q, expected one of PNBRQK, pnbrqk or a digit 1-8", c)
+			}
+			file++
+			digit = false
+		}
+		if file != 8 {
+			errs.add(FieldPlacement, r, "squares add up to %d, want 8", file)
+		}
+	}
+}
+
+// ParseCastling parses the FEN castling field, "-" or a combination of KQkq
+func ParseCastling(s string) (int, error) {
+	if s == "-" {
+		return 0, nil
+	}
+	if s == "" {
+		return 0, fmt.Errorf("empty castling rights")
+	}
+	rights := 0
+	for _, c := range s {
+		bit := 0
+		switch c {
+		case 'K':
+			bit = WhiteKingside
+		case 'Q':
+			bit = WhiteQueenside
+		case 'k':
+			bit = BlackKingside
+		case 'q':
+			bit = BlackQueenside
+		default:
+			return 0, fmt.Errorf("invalid castling right %q in %q, expected KQkq or -", c, s)
+		}
+		if rights&bit != 0 {
+			return 0, fmt.Errorf("castling right %q repeated in %q", c, s)
+		}
+		rights |= bit
+	}
+	return rights, nil
+}
\ No newline at end of file
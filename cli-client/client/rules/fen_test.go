@@ -0,0 +1,91 @@
+// FILE: lixenwraith/chess/internal/client/rules/fen_test.go
+package rules
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckFENDiagnostics(t *testing.T) {
+	// diag is one expected problem: its field, rank and part of its message
+	type diag struct {
+		field, rank int
+		msg         string
+	}
+	cases := []struct {
+		name string
+		fen  string
+		want []diag
+	}{
+		{"valid", StartFEN, nil},
+		{"valid en passant", "4k3/8/8/8/4P3/8/8/4K3 b - e3 0 1", nil},
+		{"short rank", "4k3/8/8/8/8/8/7/4K3 w - - 0 1",
+			[]diag{{FieldPlacement, 2, "squares add up to 7, want 8"}}},
+		{"long rank", "4k3/8/8/8/8/8/8/4K4 w - - 0 1",
+			[]diag{{FieldPlacement, 1, "squares add up to 9, want 8"}}},
+		{"missing rank", "4k3/8/8/8/8/8/4K3 w - - 0 1",
+			[]diag{{FieldPlacement, 0, "7 ranks separated by '/', want 8"}}},
+		{"consecutive digits", "4k3/8/8/8/8/8/44/4K3 w - - 0 1",
+			[]diag{{FieldPlacement, 2, "consecutive digits"}}},
+		{"bad piece letter", "4k3/8/8/8/8/8/8/4K2X w - - 0 1",
+			[]diag{{FieldPlacement, 1, "invalid piece letter 'X'"}}},
+		{"castling without a rook", "4k3/8/8/8/8/8/8/4K3 w K - 0 1",
+			[]diag{{FieldCastling, 0, "right K needs the king on e1 and a rook on h1"}}},
+		{"castling with a moved king", "r3k2r/8/8/8/8/8/8/R2K3R b kq - 0 1", nil},
+		{"castling without the king", "r3k2r/8/8/8/8/8/8/R2K3R w KQ - 0 1", []diag{
+			{FieldCastling, 0, "right K needs the king on e1"},
+			{FieldCastling, 0, "right Q needs the king on e1"},
+		}},
+		{"en passant on the wrong rank", "4k3/8/8/8/4P3/8/8/4K3 b - e4 0 1",
+			[]diag{{FieldEnPassant, 0, "e4 must be on rank 3 with Black to move"}}},
+		{"en passant without a pawn", "4k3/8/8/8/8/8/8/4K3 b - e3 0 1",
+			[]diag{{FieldEnPassant, 0, "e3 has no White pawn in front of it"}}},
+		{"en passant over a piece", "4k3/8/8/8/4P3/8/4N3/4K3 b - e3 0 1",
+			[]diag{{FieldEnPassant, 0, "e3 and the square behind it must be empty"}}},
+		{"pawn on the first rank", "4k3/8/8/8/8/8/8/P3K3 w - - 0 1",
+			[]diag{{FieldPlacement, 1, "pawn on a1"}}},
+		{"pawn on the last rank", "p3k3/8/8/8/8/8/8/4K3 w - - 0 1",
+			[]diag{{FieldPlacement, 8, "pawn on a8"}}},
+		{"no white king", "4k3/8/8/8/8/8/8/8 w - - 0 1",
+			[]diag{{FieldPlacement, 0, "White must have exactly one king, found 0"}}},
+		{"two black kings", "k3k3/8/8/8/8/8/8/4K3 w - - 0 1",
+			[]diag{{FieldPlacement, 0, "Black must have exactly one king, found 2"}}},
+		{"side not to move in check", "4k3/8/8/8/8/8/8/4K2r b - - 0 1",
+			[]diag{{FieldTurn, 0, "White is in check but it is Black to move"}}},
+		{"bad side to move", "4k3/8/8/8/8/8/8/4K3 x - - 0 1",
+			[]diag{{FieldTurn, 0, `"x" is not w or b`}}},
+		{"every problem at once", "4k3/8/8/8/8/8/8/4K2X x - e9 -1 0", []diag{
+			{FieldPlacement, 1, "invalid piece letter"},
+			{FieldTurn, 0, "is not w or b"},
+			{FieldEnPassant, 0, "is not a square"},
+			{FieldHalfmove, 0, "is not a non-negative number"},
+			{FieldFullmove, 0, "is not a positive number"},
+		}},
+		{"missing fields", "4k3/8/8/8/8/8/8/4K3 w", []diag{
+			{FieldCastling, 0, "missing"},
+			{FieldEnPassant, 0, "missing"},
+			{FieldHalfmove, 0, "missing"},
+			{FieldFullmove, 0, "missing"},
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := CheckFEN(tc.fen)
+			var errs FENErrors
+			if err != nil && !errors.As(err, &errs) {
+				t.Fatalf("error %v is not FENErrors", err)
+			}
+			if len(errs) != len(tc.want) {
+				t.Fatalf("got %d problem(s) %q, want %d", len(errs), err, len(tc.want))
+			}
+			for i, want := range tc.want {
+				got := errs[i]
+				if got.Field != want.field || got.Rank != want.rank || !strings.Contains(got.Msg, want.msg) {
+					t.Errorf("problem %d = field %d rank %d %q, want field %d rank %d %q",
+						i, got.Field, got.Rank, got.Msg, want.field, want.rank, want.msg)
+				}
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,122 @@
+// FILE: lixenwraith/chess/internal/client/rules/history.go
+package rules
+
+const (
+	// ClaimPlies is the halfmove clock at which the fifty-move rule allows a
+	// draw claim
+	ClaimPlies = 100
+	// WarnPlies is the halfmove clock from which the approaching fifty-move
+	// limit is pointed out
+	WarnPlies = 80
+)
+
+// History is a game replayed move by move from its start position, keeping
+// the Zobrist hash of every position to count repetitions
+type History struct {
+	positions []*Position
+	hashes    []uint64
+}
+
+// NewHistory starts a history at the given position
+func NewHistory(start *Position) *History {
+	return &History{
+		positions: []*Position{start},
+		hashes:    []uint64{start.Hash()},
+	}
+}
+
+// Replay builds the history of a game given as UCI moves from a FEN
+func Replay(fen string, moves []string) (*History, error) {
+	start, err := ParseFEN(fen)
+	if err != nil {
+		return nil, err
+	}
+	h := NewHistory(start)
+	for _, uci := range moves {
+		m, err := h.Position().ParseMove(uci)
+		if err != nil {
+			return nil, err
+		}
+		h.Play(m)
+	}
+	return h, nil
+}
+
+// Play applies a legal move
+func (h *History) Play(m Move) {
+	next := h.Position().Apply(m)
+	h.positions = append(h.positions, next)
+	h.hashes = append(h.hashes, next.Hash())
+}
+
+// Undo takes back the last count moves
+func (h *History) Undo(count int) {
+	n := len(h.positions) - count
+	h.positions = h.positions[:n:n]
+	h.hashes = h.hashes[:n:n]
+}
+
+// Position returns the current position
+func (h *History) Position() *Position {
+	return h.positions[len(h.positions)-1]
+}
+
+// Positions returns the start position followed by one per move
+func (h *History) Positions() []*Position {
+	return h.positions
+}
+
+// Plies returns the number of moves played
+func (h *History) Plies() int {
+	return len(h.positions) - 1
+}
+
+// Repetitions counts the occurrences of the current position, itself
+// included
+func (h *History) Repetitions() int {
+	last := len(h.hashes) - 1
+	return h.occurrences(h.hashes[last], h.positions[last].Halfmove, last) + 1
+}
+
+// RepetitionsAfter counts the occurrences the position after m would have
+func (h *History) RepetitionsAfter(m Move) int {
+	next := h.Position().Apply(m)
+	return h.occurrences(next.Hash(), next.Halfmove, len(h.hashes)) + 1
+}
+
+// occurrences counts hash among the positions before index end with the
+// same side to move, going back no further than the halfmove clock allows
+// since a capture or pawn move cannot be undone
+func (h *History) occurrences(hash uint64, halfmove, end int) int {
+	n := 0
+	for i := end - 2; i >= 0 && i >= end-halfmove; i -= 2 {
+		if h.hashes[i] == hash {
+			n++
+		}
+	}
+	return n
+}
+
+// after returns a copy of the history with m played, leaving h unchanged
+func (h *History) after(m Move) *History {
+	n := len(h.positions)
+	next := &History{positions: h.positions[:n:n], hashes: h.hashes[:n:n]}
+	next.Play(m)
+	return next
+}
+
+// RepetitionClaim reports whether playing m lets the opponent claim a draw
+// by threefold repetition, because m repeats a position for the third time
+// or because some reply does; that reply is returned, nil in the first case
+func (h *History) RepetitionClaim(m Move) (bool, *Move) {
+	if h.RepetitionsAfter(m) >= 3 {
+		return true, nil
+	}
+	next := h.after(m)
+	for _, reply := range next.Position().LegalMoves() {
+		if next.RepetitionsAfter(reply) >= 3 {
+			return true, &reply
+		}
+	}
+	return false, nil
+}
\ No newline at end of file
@@ -0,0 +1,342 @@
+// FILE: lixenwraith/chess/internal/client/rules/movegen.go
+package rules
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Move is a single move in from/to form; Promo holds the lowercase
+// promotion piece letter or 0
+type Move struct {
+	From  int
+	To    int
+	Promo byte
+}
+
+// String renders the move in UCI notation
+func (m Move) String() string {
+	s := SquareName(m.From) + SquareName(m.To)
+	if m.Promo != 0 {
+		s += string(m.Promo)
+	}
+	return s
+}
+
+var (
+	knightSteps = [][2]int{{1, 2}, {2, 1}, {2, -1}, {1, -2}, {-1, -2}, {-2, -1}, {-2, 1}, {-1, 2}}
+	kingSteps   = [][2]int{{1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}, {0, -1}, {1, -1}}
+	rookDirs    = [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+	bishopDirs  = [][2]int{{1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+)
+
+// offset returns the square df files and dr ranks away, or false if off-board
+func offset(sq, df, dr int) (int, bool) {
+	f, r := sq%8+df, sq/8+dr
+	if f < 0 || f > 7 || r < 0 || r > 7 {
+		return 0, false
+	}
+	return r*8 + f, true
+}
+
+// ParseMove resolves a UCI string to a legal move in the position
+func (p *Position) ParseMove(uci string) (Move, error) {
+	uci = strings.ToLower(strings.TrimSpace(uci))
+	if len(uci) != 4 && len(uci) != 5 {
+		return Move{}, fmt.Errorf("invalid move format: %s", uci)
+	}
+	from, ok1 := ParseSquare(uci[0:2])
+	to, ok2 := ParseSquare(uci[2:4])
+	if !ok1 || !ok2 {
+		return Move{}, fmt.Errorf("invalid move format: %s", uci)
+	}
+	var promo byte
+	if len(uci) == 5 {
+		promo = uci[4]
+	}
+
+	for _, m := range p.LegalMoves() {
+		if m.From == from && m.To == to && m.Promo == promo {
+			return m, nil
+		}
+	}
+	return Move{}, fmt.Errorf("illegal move: %s", uci)
+}
+
+// LegalMoves returns every legal move for the side to move
+func (p *Position) LegalMoves() []Move {
+	pseudo := p.pseudoMoves(false)
+	legal := pseudo[:0]
+	for _, m := range pseudo {
+		next := p.Apply(m)
+		if !next.IsAttacked(next.KingSquare(p.Turn), p.Turn.Other()) {
+			legal = append(legal, m)
+		}
+	}
+	return legal
+}
+
+// LegalCaptures returns legal captures and promotions only
+func (p *Position) LegalCaptures() []Move {
+	pseudo := p.pseudoMoves(true)
+	legal := pseudo[:0]
+	for _, m := range pseudo {
+		next := p.Apply(m)
+		if !next.IsAttacked(next.KingSquare(p.Turn), p.Turn.Other()) {
+			legal = append(legal, m)
+		}
+	}
+	return legal
+}
+
+// IsCapture reports whether the move takes a piece, including en passant
+func (p *Position) IsCapture(m Move) bool {
+	if p.Board[m.To] != 0 {
+		return true
+	}
+	return Kind(p.Board[m.From]) == 'p' && m.To == p.EnPassant
+}
+
+func (p *Position) pseudoMoves(capturesOnly bool) []Move {
+	moves := make([]Move, 0, 48)
+	us := p.Turn
+
+	enemy := func(sq int) bool {
+		pc := p.Board[sq]
+		return pc != 0 && ColorOf(pc) != us
+	}
+	add := func(from, to int) {
+		if p.Board[to] == 0 && capturesOnly {
+			return
+		}
+		moves = append(moves, Move{From: from, To: to})
+	}
+
+	for sq, pc := range p.Board {
+		if pc == 0 || ColorOf(pc) != us {
+			continue
+		}
+		switch Kind(pc) {
+		case 'p':
+			moves = p.pawnMoves(moves, sq, capturesOnly)
+		case 'n':
+			for _, s := range knightSteps {
+				if to, ok := offset(sq, s[0], s[1]); ok && (p.Board[to] == 0 || enemy(to)) {
+					add(sq, to)
+				}
+			}
+		case 'k':
+			for _, s := range kingSteps {
+				if to, ok := offset(sq, s[0], s[1]); ok && (p.Board[to] == 0 || enemy(to)) {
+					add(sq, to)
+				}
+			}
+			if !capturesOnly {
+				moves = p.castlingMoves(moves, sq)
+			}
+		case 'b', 'r', 'q':
+			var dirs [][2]int
+			if Kind(pc) != 'r' {
+				dirs = append(dirs, bishopDirs...)
+			}
+			if Kind(pc) != 'b' {
+				dirs = append(dirs, rookDirs...)
+			}
+			for _, d := range dirs {
+				to, ok := offset(sq, d[0], d[1])
+				for ok {
+					if p.Board[to] != 0 {
+						if enemy(to) {
+							add(sq, to)
+						}
+						break
+					}
+					add(sq, to)
+					to, ok = offset(to, d[0], d[1])
+				}
+			}
+		}
+	}
+	return moves
+}
+
+func (p *Position) pawnMoves(moves []Move, sq int, capturesOnly bool) []Move {
+	dir, startRank, lastRank := 1, 1, 7
+	if p.Turn == Black {
+		dir, startRank, lastRank = -1, 6, 0
+	}
+
+	addPawn := func(to int) {
+		if to/8 == lastRank {
+			for _, promo := range []byte{'q', 'r', 'b', 'n'} {
+				moves = append(moves, Move{From: sq, To: to, Promo: promo})
+			}
+			return
+		}
+		moves = append(moves, Move{From: sq, To: to})
+	}
+
+	if to, ok := offset(sq, 0, dir); ok && p.Board[to] == 0 {
+		if !capturesOnly || to/8 == lastRank {
+			addPawn(to)
+		}
+		if to2, ok := offset(to, 0, dir); ok && sq/8 == startRank && p.Board[to2] == 0 && !capturesOnly {
+			addPawn(to2)
+		}
+	}
+	for _, df := range []int{-1, 1} {
+		to, ok := offset(sq, df, dir)
+		if !ok {
+			continue
+		}
+		if pc := p.Board[to]; (pc != 0 && ColorOf(pc) != p.Turn) || to == p.EnPassant {
+			addPawn(to)
+		}
+	}
+	return moves
+}
+
+func (p *Position) castlingMoves(moves []Move, king int) []Move {
+	them := p.Turn.Other()
+	kingside, queenside, home := WhiteKingside, WhiteQueenside, 4
+	if p.Turn == Black {
+		kingside, queenside, home = BlackKingside, BlackQueenside, 60
+	}
+	if king != home || p.IsAttacked(home, them) {
+		return moves
+	}
+
+	rook := PieceOf('r', p.Turn)
+	if p.Castling&kingside != 0 && p.Board[home+3] == rook &&
+		p.Board[home+1] == 0 && p.Board[home+2] == 0 &&
+		!p.IsAttacked(home+1, them) && !p.IsAttacked(home+2, them) {
+		moves = append(moves, Move{From: home, To: home + 2})
+	}
+	if p.Castling&queenside != 0 && p.Board[home-4] == rook &&
+		p.Board[home-1] == 0 && p.Board[home-2] == 0 && p.Board[home-3] == 0 &&
+		!p.IsAttacked(home-1, them) && !p.IsAttacked(home-2, them) {
+		moves = append(moves, Move{From: home, To: home - 2})
+	}
+	return moves
+}
+
+// KingSquare returns the square of the given side's king, or NoSquare
+func (p *Position) KingSquare(c Color) int {
+	king := PieceOf('k', c)
+	for sq, pc := range p.Board {
+		if pc == king {
+			return sq
+		}
+	}
+	return NoSquare
+}
+
+// IsAttacked reports whether any piece of color by attacks sq
+func (p *Position) IsAttacked(sq int, by Color) bool {
+	if sq == NoSquare {
+		return false
+	}
+
+	pawnRank := -1 // attacking white pawns sit below the square
+	if by == Black {
+		pawnRank = 1
+	}
+	for _, df := range []int{-1, 1} {
+		if from, ok := offset(sq, df, pawnRank); ok && p.Board[from] == PieceOf('p', by) {
+			return true
+		}
+	}
+	for _, s := range knightSteps {
+		if from, ok := offset(sq, s[0], s[1]); ok && p.Board[from] == PieceOf('n', by) {
+			return true
+		}
+	}
+	for _, s := range kingSteps {
+		if from, ok := offset(sq, s[0], s[1]); ok && p.Board[from] == PieceOf('k', by) {
+			return true
+		}
+	}
+
+	slide := func(dirs [][2]int, kinds string) bool {
+		for _, d := range dirs {
+			from, ok := offset(sq, d[0], d[1])
+			for ok {
+				if pc := p.Board[from]; pc != 0 {
+					if ColorOf(pc) == by && strings.IndexByte(kinds, Kind(pc)) >= 0 {
+						return true
+					}
+					break
+				}
+				from, ok = offset(from, d[0], d[1])
+			}
+		}
+		return false
+	}
+	return slide(rookDirs, "rq") || slide(bishopDirs, "bq")
+}
+
+// InCheck reports whether the side to move is in check
+func (p *Position) InCheck() bool {
+	return p.IsAttacked(p.KingSquare(p.Turn), p.Turn.Other())
+}
+
+// Apply returns the position after playing m, which must be at least
+// pseudo-legal
+func (p *Position) Apply(m Move) *Position {
+	next := *p
+	pc := p.Board[m.From]
+	captured := p.Board[m.To]
+
+	if Kind(pc) == 'p' && m.To == p.EnPassant && captured == 0 {
+		next.Board[m.From/8*8+m.To%8] = 0
+		captured = PieceOf('p', p.Turn.Other())
+	}
+	if Kind(pc) == 'k' && (m.To-m.From == 2 || m.From-m.To == 2) {
+		rookFrom, rookTo := m.From+3, m.From+1
+		if m.To < m.From {
+			rookFrom, rookTo = m.From-4, m.From-1
+		}
+		next.Board[rookTo], next.Board[rookFrom] = next.Board[rookFrom], 0
+	}
+
+	next.Board[m.To], next.Board[m.From] = pc, 0
+	if m.Promo != 0 {
+		next.Board[m.To] = PieceOf(m.Promo, p.Turn)
+	}
+
+	next.EnPassant = NoSquare
+	if Kind(pc) == 'p' && (m.To-m.From == 16 || m.From-m.To == 16) {
+		next.EnPassant = (m.From + m.To) / 2
+	}
+
+	next.Castling &^= castlingLoss(m.From) | castlingLoss(m.To)
+
+	if Kind(pc) == 'p' || captured != 0 {
+		next.Halfmove = 0
+	} else {
+		next.Halfmove++
+	}
+	if p.Turn == Black {
+		next.Fullmove++
+	}
+	next.Turn = p.Turn.Other()
+	return &next
+}
+
+func castlingLoss(sq int) int {
+	switch sq {
+	case 0:
+		return WhiteQueenside
+	case 4:
+		return WhiteKingside | WhiteQueenside
+	case 7:
+		return WhiteKingside
+	case 56:
+		return BlackQueenside
+	case 60:
+		return BlackKingside | BlackQueenside
+	case 63:
+		return BlackKingside
+	}
+	return 0
+}
@@ -0,0 +1,173 @@
+// FILE: lixenwraith/chess/internal/client/rules/position.go
+// Package rules implements chess positions, legal move generation and game
+// termination for client-side play and analysis.
+package rules
+
+import (
+	"fmt"
+	"strings"
+)
+
+const StartFEN = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+
+// Color is the side to move, 'w' or 'b' as in FEN
+type Color byte
+
+const (
+	White Color = 'w'
+	Black Color = 'b'
+)
+
+// Other returns the opposing color
+func (c Color) Other() Color {
+	if c == White {
+		return Black
+	}
+	return White
+}
+
+func (c Color) String() string {
+	if c == White {
+		return "White"
+	}
+	return "Black"
+}
+
+// Castling rights bits
+const (
+	WhiteKingside = 1 << iota
+	WhiteQueenside
+	BlackKingside
+	BlackQueenside
+)
+
+// NoSquare marks an absent en passant target
+const NoSquare = -1
+
+// Position is a complete chess position. Board uses FEN piece letters
+// (uppercase white, lowercase black, 0 for empty) indexed a1=0 .. h8=63
+type Position struct {
+	Board     [64]byte
+	Turn      Color
+	Castling  int
+	EnPassant int
+	Halfmove  int
+	Fullmove  int
+}
+
+// Start returns the standard initial position
+func Start() *Position {
+	p, _ := ParseFEN(StartFEN)
+	return p
+}
+
+// FEN renders the position in Forsyth-Edwards Notation
+func (p *Position) FEN() string {
+	var b strings.Builder
+	b.WriteString(p.placement())
+	fmt.Fprintf(&b, " %c %s %s %d %d", p.Turn, p.castlingString(), p.enPassantString(), p.Halfmove, p.Fullmove)
+	return b.String()
+}
+
+func (p *Position) placement() string {
+	var b strings.Builder
+	for rank := 7; rank >= 0; rank-- {
+		empty := 0
+		for file := 0; file < 8; file++ {
+			pc := p.Board[rank*8+file]
+			if pc == 0 {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				b.WriteByte(byte('0' + empty))
+				empty = 0
+			}
+			b.WriteByte(pc)
+		}
+		if empty > 0 {
+			b.WriteByte(byte('0' + empty))
+		}
+		if rank > 0 {
+			b.WriteByte('/')
+		}
+	}
+	return b.String()
+}
+
+func (p *Position) castlingString() string {
+	s := ""
+	for _, r := range []struct {
+		bit int
+		c   string
+	}{{WhiteKingside, "K"}, {WhiteQueenside, "Q"}, {BlackKingside, "k"}, {BlackQueenside, "q"}} {
+		if p.Castling&r.bit != 0 {
+			s += r.c
+		}
+	}
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func (p *Position) enPassantString() string {
+	if p.EnPassant == NoSquare {
+		return "-"
+	}
+	return SquareName(p.EnPassant)
+}
+
+// ASCII renders the board in the server's text layout
+func (p *Position) ASCII() string {
+	var b strings.Builder
+	b.WriteString("  a b c d e f g h\n")
+	for rank := 7; rank >= 0; rank-- {
+		fmt.Fprintf(&b, "%d ", rank+1)
+		for file := 0; file < 8; file++ {
+			pc := p.Board[rank*8+file]
+			if pc == 0 {
+				pc = '.'
+			}
+			b.WriteByte(pc)
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%d\n", rank+1)
+	}
+	b.WriteString("  a b c d e f g h\n")
+	return b.String()
+}
+
+// ParseSquare converts algebraic notation like "e4" to a square index
+func ParseSquare(s string) (int, bool) {
+	if len(s) != 2 || s[0] < 'a' || s[0] > 'h' || s[1] < '1' || s[1] > '8' {
+		return 0, false
+	}
+	return int(s[1]-'1')*8 + int(s[0]-'a'), true
+}
+
+// SquareName converts a square index to algebraic notation
+func SquareName(sq int) string {
+	return string([]byte{byte('a' + sq%8), byte('1' + sq/8)})
+}
+
+// ColorOf returns the color of a piece letter
+func ColorOf(pc byte) Color {
+	if pc >= 'A' && pc <= 'Z' {
+		return White
+	}
+	return Black
+}
+
+// Kind returns the lowercase piece letter regardless of color
+func Kind(pc byte) byte {
+	return pc | 0x20
+}
+
+// PieceOf returns the piece letter of the given kind for a color
+func PieceOf(kind byte, c Color) byte {
+	if c == White {
+		return kind &^ 0x20
+	}
+	return kind | 0x20
+}
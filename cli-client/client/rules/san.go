@@ -0,0 +1,90 @@
+// FILE: lixenwraith/chess/internal/client/rules/san.go
+package rules
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SAN renders a legal move in Standard Algebraic Notation, with + or #
+// when it gives check or mate
+func (p *Position) SAN(m Move) string {
+	san := p.sanBase(m)
+	next := p.Apply(m)
+	if next.InCheck() {
+		if len(next.LegalMoves()) == 0 {
+			return san + "#"
+		}
+		return san + "+"
+	}
+	return san
+}
+
+// ParseSAN finds the legal move written in Standard Algebraic Notation;
+// check marks and annotations are ignored
+func (p *Position) ParseSAN(san string) (Move, error) {
+	s := strings.TrimRight(strings.TrimSpace(san), "+#!?")
+	s = strings.ReplaceAll(s, "0", "O")
+	for _, m := range p.LegalMoves() {
+		if p.sanBase(m) == s {
+			return m, nil
+		}
+	}
+	return Move{}, fmt.Errorf("illegal move: %s", san)
+}
+
+// sanBase renders a move in SAN without the check suffix
+func (p *Position) sanBase(m Move) string {
+	pc := p.Board[m.From]
+	var b strings.Builder
+
+	switch {
+	case Kind(pc) == 'k' && m.To-m.From == 2:
+		b.WriteString("O-O")
+	case Kind(pc) == 'k' && m.From-m.To == 2:
+		b.WriteString("O-O-O")
+	case Kind(pc) == 'p':
+		if p.IsCapture(m) {
+			b.WriteByte(SquareName(m.From)[0])
+			b.WriteByte('x')
+		}
+		b.WriteString(SquareName(m.To))
+		if m.Promo != 0 {
+			b.WriteByte('=')
+			b.WriteByte(PieceOf(m.Promo, White))
+		}
+	default:
+		b.WriteByte(PieceOf(Kind(pc), White))
+		b.WriteString(p.disambiguate(m))
+		if p.IsCapture(m) {
+			b.WriteByte('x')
+		}
+		b.WriteString(SquareName(m.To))
+	}
+	return b.String()
+}
+
+// disambiguate returns the origin file, rank or square needed when another
+// piece of the same kind can reach the same square
+func (p *Position) disambiguate(m Move) string {
+	pc := p.Board[m.From]
+	sameFile, sameRank, other := false, false, false
+	for _, o := range p.LegalMoves() {
+		if o.To != m.To || o.From == m.From || p.Board[o.From] != pc {
+			continue
+		}
+		other = true
+		sameFile = sameFile || o.From%8 == m.From%8
+		sameRank = sameRank || o.From/8 == m.From/8
+	}
+	from := SquareName(m.From)
+	switch {
+	case !other:
+		return ""
+	case !sameFile:
+		return from[:1]
+	case !sameRank:
+		return from[1:]
+	}
+	return from
+}
\ No newline at end of file
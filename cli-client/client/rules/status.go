@@ -0,0 +1,81 @@
+// FILE: lixenwraith/chess/internal/client/rules/status.go
+package rules
+
+// Status describes whether a position ends the game
+type Status int
+
+const (
+	Ongoing Status = iota
+	Checkmate
+	Stalemate
+	SeventyFiveMoves
+	InsufficientMaterial
+)
+
+const (
+	// AutoDrawPlies is the halfmove clock at which the seventy-five-move rule
+	// ends the game without a claim
+	AutoDrawPlies = 150
+	// AutoDrawRepetitions is the number of occurrences of a position at
+	// which the game ends without a claim
+	AutoDrawRepetitions = 5
+)
+
+func (s Status) String() string {
+	switch s {
+	case Checkmate:
+		return "checkmate"
+	case Stalemate:
+		return "stalemate"
+	case SeventyFiveMoves:
+		return "seventy-five-move rule"
+	case InsufficientMaterial:
+		return "insufficient material"
+	}
+	return "ongoing"
+}
+
+// Status reports the terminal state of the position. Only rules that end the
+// game without a claim apply; repetition needs the game history and is
+// detected by the caller
+func (p *Position) Status() Status {
+	if len(p.LegalMoves()) == 0 {
+		if p.InCheck() {
+			return Checkmate
+		}
+		return Stalemate
+	}
+	if p.InsufficientMaterial() {
+		return InsufficientMaterial
+	}
+	if p.Halfmove >= AutoDrawPlies {
+		return SeventyFiveMoves
+	}
+	return Ongoing
+}
+
+// InsufficientMaterial reports dead positions: kings plus a single knight, or
+// kings plus bishops that all stand on one square color
+func (p *Position) InsufficientMaterial() bool {
+	knights := 0
+	bishopSquareColors := map[int]bool{}
+	for sq, pc := range p.Board {
+		if pc == 0 {
+			continue
+		}
+		switch Kind(pc) {
+		case 'k':
+		case 'n':
+			knights++
+		case 'b':
+			bishopSquareColors[(sq/8+sq%8)%2] = true
+		default:
+			return false
+		}
+	}
+	if knights == 0 {
+		// Any number of bishops confined to one square color cannot mate
+		return len(bishopSquareColors) <= 1
+	}
+	return knights == 1 && len(bishopSquareColors) == 0
+}
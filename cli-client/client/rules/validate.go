@@ -0,0 +1,78 @@
+// FILE: lixenwraith/chess/internal/client/rules/validate.go
+package rules
+
+// castlingSquares lists the king and rook squares each castling right needs
+var castlingSquares = []struct {
+	bit        int
+	name       string
+	king, rook int
+	color      Color
+}{
+	{WhiteKingside, "K", 4, 7, White},
+	{WhiteQueenside, "Q", 4, 0, White},
+	{BlackKingside, "k", 60, 63, Black},
+	{BlackQueenside, "q", 60, 56, Black},
+}
+
+// Validate reports why the position could not arise in a game: missing or
+// extra kings, pawns on the first or last rank, the side not to move in
+// check, or castling and en passant fields that contradict the board. All
+// problems are returned together as FENErrors
+func (p *Position) Validate() error {
+	var errs FENErrors
+	for _, c := range []Color{White, Black} {
+		kings := 0
+		for _, pc := range p.Board {
+			if pc == PieceOf('k', c) {
+				kings++
+			}
+		}
+		if kings != 1 {
+			errs.add(FieldPlacement, 0, "%s must have exactly one king, found %d", c, kings)
+		}
+	}
+
+	for file := 0; file < 8; file++ {
+		for _, sq := range []int{file, 56 + file} {
+			if pc := p.Board[sq]; pc != 0 && Kind(pc) == 'p' {
+				errs.add(FieldPlacement, sq/8+1, "pawn on %s, pawns cannot stand on the first or last rank", SquareName(sq))
+			}
+		}
+	}
+
+	if p.IsAttacked(p.KingSquare(p.Turn.Other()), p.Turn) {
+		errs.add(FieldTurn, 0, "%s is in check but it is %s to move", p.Turn.Other(), p.Turn)
+	}
+
+	for _, r := range castlingSquares {
+		if p.Castling&r.bit == 0 {
+			continue
+		}
+		if p.Board[r.king] != PieceOf('k', r.color) || p.Board[r.rook] != PieceOf('r', r.color) {
+			errs.add(FieldCastling, 0, "right %s needs the king on %s and a rook on %s",
+				r.name, SquareName(r.king), SquareName(r.rook))
+		}
+	}
+
+	if p.EnPassant != NoSquare {
+		// The pawn that just moved stands in front of the target square, as
+		// seen from the side to move, and passed over it from an empty square
+		rank, pushed, from := 5, p.EnPassant-8, p.EnPassant+8
+		if p.Turn == Black {
+			rank, pushed, from = 2, p.EnPassant+8, p.EnPassant-8
+		}
+		switch {
+		case p.EnPassant/8 != rank:
+			errs.add(FieldEnPassant, 0, "%s must be on rank %d with %s to move",
+				SquareName(p.EnPassant), rank+1, p.Turn)
+		case p.Board[pushed] != PieceOf('p', p.Turn.Other()):
+			errs.add(FieldEnPassant, 0, "%s has no %s pawn in front of it",
+				SquareName(p.EnPassant), p.Turn.Other())
+		case p.Board[p.EnPassant] != 0 || p.Board[from] != 0:
+			errs.add(FieldEnPassant, 0, "%s and the square behind it must be empty",
+				SquareName(p.EnPassant))
+		}
+	}
+
+	return errs.err()
+}
\ No newline at end of file
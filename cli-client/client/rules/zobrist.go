@@ -0,0 +1,82 @@
+// FILE: lixenwraith/chess/internal/client/rules/zobrist.go
+package rules
+
+import "strings"
+
+// pieceLetters orders the pieces for the Zobrist piece keys
+const pieceLetters = "PNBRQKpnbrqk"
+
+// Zobrist keys, drawn from a fixed seed so hashes are stable between runs
+var (
+	zobristPieces    [12][64]uint64
+	zobristCastling  [4]uint64
+	zobristEnPassant [8]uint64
+	zobristBlack     uint64
+)
+
+func init() {
+	// splitmix64
+	state := uint64(0x9e3779b97f4a7c15)
+	next := func() uint64 {
+		state += 0x9e3779b97f4a7c15
+		z := state
+		z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+		z = (z ^ z>>27) * 0x94d049bb133111eb
+		return z ^ z>>31
+	}
+	for i := range zobristPieces {
+		for sq := range zobristPieces[i] {
+			zobristPieces[i][sq] = next()
+		}
+	}
+	for i := range zobristCastling {
+		zobristCastling[i] = next()
+	}
+	for i := range zobristEnPassant {
+		zobristEnPassant[i] = next()
+	}
+	zobristBlack = next()
+}
+
+// Hash returns the Zobrist hash of the position for repetition purposes:
+// placement, side to move, castling rights, and the en passant file only
+// when a pawn stands ready to capture there, as positions differing in an
+// unusable en passant square count as the same
+func (p *Position) Hash() uint64 {
+	var h uint64
+	for sq, pc := range p.Board {
+		if pc != 0 {
+			h ^= zobristPieces[strings.IndexByte(pieceLetters, pc)][sq]
+		}
+	}
+	if p.Turn == Black {
+		h ^= zobristBlack
+	}
+	for i := range zobristCastling {
+		if p.Castling&(1<<i) != 0 {
+			h ^= zobristCastling[i]
+		}
+	}
+	if p.enPassantCapturable() {
+		h ^= zobristEnPassant[p.EnPassant%8]
+	}
+	return h
+}
+
+// enPassantCapturable reports whether a pawn of the side to move stands
+// next to the pawn that just made a double step. Unvalidated positions can
+// carry the square on any rank; only the sixth (third for Black) counts
+func (p *Position) enPassantCapturable() bool {
+	if p.EnPassant == NoSquare {
+		return false
+	}
+	pawn, from, rank := byte('P'), p.EnPassant-8, 5
+	if p.Turn == Black {
+		pawn, from, rank = 'p', p.EnPassant+8, 2
+	}
+	if p.EnPassant/8 != rank {
+		return false
+	}
+	file := p.EnPassant % 8
+	return (file > 0 && p.Board[from-1] == pawn) || (file < 7 && p.Board[from+1] == pawn)
+}
\ No newline at end of file
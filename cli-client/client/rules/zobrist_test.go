@@ -0,0 +1,46 @@
+// FILE: lixenwraith/chess/internal/client/rules/zobrist_test.go
+package rules
+
+import "testing"
+
+func TestHashEnPassant(t *testing.T) {
+	hash := func(fen string) uint64 {
+		t.Helper()
+		p, err := ParseFEN(fen)
+		if err != nil {
+			t.Fatalf("%s: %v", fen, err)
+		}
+		return p.Hash()
+	}
+
+	// Squares off the third and sixth ranks come from unchecked input and
+	// must neither panic nor change the hash
+	cases := []struct {
+		name string
+		base string // FEN up to the en passant field
+		ep   string
+		want bool // whether the en passant square is part of the hash
+	}{
+		{"rank 1", "8/8/8/8/8/8/8/8 w -", "a1", false},
+		{"rank 8", "8/8/8/8/8/8/8/8 b -", "h8", false},
+		{"rank 8 with white to move", "4k3/8/8/8/8/8/8/4K3 w -", "a8", false},
+		{"rank 1 with black to move", "4k3/8/8/8/8/8/8/4K3 b -", "h1", false},
+		{"third rank with white to move", "4k3/8/8/8/3pP3/8/8/4K3 w -", "e3", false},
+		{"sixth rank with black to move", "4k3/8/8/3Pp3/8/8/8/4K3 b -", "e6", false},
+		{"no pawn beside", "4k3/8/8/8/4P3/8/8/4K3 b -", "e3", false},
+		{"capturing pawn on the left", "4k3/8/8/8/3pP3/8/8/4K3 b -", "e3", true},
+		{"capturing pawn on the right", "4k3/8/8/8/4Pp2/8/8/4K3 b -", "e3", true},
+		{"white to capture on the a-file", "4k3/8/8/pP6/8/8/8/4K3 w -", "a6", true},
+		{"white pawn on the far side of the h-file", "4k3/8/8/P6p/8/8/8/4K3 w -", "h6", false},
+	}
+	for _, tc := range cases {
+		with, without := tc.base+" "+tc.ep+" 0 1", tc.base+" - 0 1"
+		if got := hash(with) != hash(without); got != tc.want {
+			t.Errorf("%s: en passant hashed %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	if _, err := Replay("8/8/8/8/8/8/8/8 w - a1 0 1", nil); err != nil {
+		t.Fatal(err)
+	}
+}
\ No newline at end of file
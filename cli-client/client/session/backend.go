@@ -0,0 +1,76 @@
+// FILE: lixenwraith/chess/internal/client/session/backend.go
+package session
+
+import (
+	"chess/internal/client/api"
+)
+
+// Backend is the transport used by command handlers. The HTTP client is the
+// primary implementation; the offline local client is another, and wrappers
+// such as recorders can be swapped in without touching the commands
+type Backend interface {
+	Health() (*api.HealthResponse, error)
+
+	// Games
+	CreateGame(req *api.CreateGameRequest) (*api.GameResponse, error)
+	GetGame(gameID string) (*api.GameResponse, error)
+	GetGameWithPoll(gameID string, moveCount int) (*api.GameResponse, error)
+	DeleteGame(gameID string) error
+	MakeMove(gameID string, move string) (*api.GameResponse, error)
+	UndoMoves(gameID string, count int) (*api.GameResponse, error)
+	Resign(gameID string, color api.Color) (*api.GameResponse, error)
+	Draw(gameID string, req *api.DrawRequest) (*api.GameResponse, error)
+	GetBoard(gameID string) (*api.BoardResponse, error)
+
+	// Auth
+	Register(username, password, email string) (*api.AuthResponse, error)
+	Login(identifier, password string) (*api.AuthResponse, error)
+	GetCurrentUser() (*api.UserResponse, error)
+	SetToken(token string)
+}
+
+// Tracer is implemented by backends that can print their traffic
+type Tracer interface {
+	SetVerbose(v bool)
+}
+
+// Remote is implemented by backends that talk to a configurable server
+type Remote interface {
+	SetBaseURL(url string)
+	RawRequest(method, path string, body string) error
+}
+
+// Recorder is implemented by backends that can capture their traffic to a
+// cassette file
+type Recorder interface {
+	StartRecording(path string) error
+	StopRecording() (path string, count int, err error)
+	IsRecording() (path string, ok bool)
+}
+
+// Historian is implemented by backends that keep a log of their API calls
+type Historian interface {
+	History() []*api.Exchange
+	ClearHistory()
+}
+
+// StatsReporter is implemented by backends that measure their API calls
+type StatsReporter interface {
+	Stats() []api.RouteStats
+	ResetStats()
+}
+
+// Lister is implemented by backends that can enumerate games
+type Lister interface {
+	ListGames(q *api.ListGamesQuery) (*api.GameListResponse, error)
+}
+
+var (
+	_ Backend       = (*api.Client)(nil)
+	_ Lister        = (*api.Client)(nil)
+	_ Historian     = (*api.Client)(nil)
+	_ StatsReporter = (*api.Client)(nil)
+	_ Tracer        = (*api.Client)(nil)
+	_ Remote        = (*api.Client)(nil)
+	_ Recorder      = (*api.Client)(nil)
+)
@@ -0,0 +1,73 @@
+// FILE: lixenwraith/chess/internal/client/session/clock.go
+package session
+
+import (
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/clock"
+)
+
+// GameClock is the client-side clock of one game. The clock is pressed as
+// moves show up in game states, so it counts the time until the client
+// learns of a move
+type GameClock struct {
+	*clock.Clock
+	GameID    string
+	StartPly  int  // game ply the clock started at
+	Announced bool // flag fall has been reported to the user
+}
+
+// SetClock attaches a clock to a game, nil removes it
+func (s *Session) SetClock(gc *GameClock) {
+	s.mu.Lock()
+	s.gameClock = gc
+	s.mu.Unlock()
+}
+
+// GetClock returns the clock of gameID, nil if it has none
+func (s *Session) GetClock(gameID string) *GameClock {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.gameClock == nil || s.gameClock.GameID != gameID {
+		return nil
+	}
+	return s.gameClock
+}
+
+// ClaimFlag marks the flag fall of gameID's clock as reported, false if it
+// was reported already or the game has no clock
+func (s *Session) ClaimFlag(gameID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	gc := s.gameClock
+	if gc == nil || gc.GameID != gameID || gc.Announced {
+		return false
+	}
+	gc.Announced = true
+	return true
+}
+
+// tickClockLocked presses or takes back the clock for plies the game
+// gained or lost since the clock last saw it
+func (s *Session) tickClockLocked(game *api.GameResponse) {
+	gc := s.gameClock
+	if gc == nil || gc.GameID != game.GameID {
+		return
+	}
+	now := time.Now()
+	ply := gc.StartPly + gc.Plies()
+	switch {
+	case len(game.Moves) > ply:
+		for ; ply < len(game.Moves); ply++ {
+			if !gc.Press(now) {
+				break
+			}
+		}
+	case len(game.Moves) < ply:
+		gc.Undo(ply-len(game.Moves), now)
+	}
+	if game.State.IsOver() {
+		gc.Stop(now)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,32 @@
+// FILE: lixenwraith/chess/internal/client/session/engine.go
+package session
+
+import (
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/uci"
+)
+
+// EngineSeat is a local UCI engine playing one side of a game; the server
+// sees that side as a human whose moves this client submits
+type EngineSeat struct {
+	Engine   *uci.Engine
+	GameID   string
+	Color    api.Color
+	MoveTime time.Duration
+}
+
+// SetEngineSeat attaches a local engine, nil detaches it
+func (s *Session) SetEngineSeat(seat *EngineSeat) {
+	s.mu.Lock()
+	s.engineSeat = seat
+	s.mu.Unlock()
+}
+
+// GetEngineSeat returns the attached local engine, nil if none
+func (s *Session) GetEngineSeat() *EngineSeat {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.engineSeat
+}
\ No newline at end of file
@@ -0,0 +1,220 @@
+// FILE: lixenwraith/chess/internal/client/session/games.go
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"chess/internal/client/api"
+)
+
+// MaxGameLog bounds the number of games remembered across restarts
+const MaxGameLog = 200
+
+// OfflineServer is the Server recorded for games hosted in-process
+const OfflineServer = "offline"
+
+// GameRecord is a game this client created or joined
+type GameRecord struct {
+	GameID   string         `json:"gameId"`
+	Server   string         `json:"server"` // API base URL or OfflineServer
+	Color    api.Color      `json:"color,omitempty"`
+	White    api.PlayerType `json:"white"`
+	Black    api.PlayerType `json:"black"`
+	State    api.GameState  `json:"state"`
+	Moves    int            `json:"moves"`
+	StartFEN string         `json:"startFen,omitempty"` // known when recorded before the first move
+	Created  time.Time      `json:"created"`
+	Updated  time.Time      `json:"updated"`
+}
+
+// DefaultGameLogPath returns the per-user game log file, "" when the
+// platform has no config directory
+func DefaultGameLogPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "chess-client", "games.json")
+}
+
+// LoadGameLog reads the game log at path and persists later changes there;
+// a missing file starts an empty log
+func (s *Session) LoadGameLog(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gameLogPath = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &s.gameLog)
+}
+
+// RecordGame adds or refreshes a game in the log
+func (s *Session) RecordGame(game *api.GameResponse) {
+	s.mu.Lock()
+
+	server := s.serverLocked()
+	now := time.Now()
+	i := s.findRecordLocked(game.GameID, server)
+	if i < 0 {
+		s.gameLog = append(s.gameLog, GameRecord{
+			GameID:  game.GameID,
+			Server:  server,
+			White:   game.Players.White.Type,
+			Black:   game.Players.Black.Type,
+			Created: now,
+		})
+		i = len(s.gameLog) - 1
+		if len(game.Moves) == 0 {
+			s.gameLog[i].StartFEN = game.FEN
+		}
+	}
+	rec := &s.gameLog[i]
+	switch {
+	case s.playerColor.Valid():
+		rec.Color = s.playerColor
+	case game.Players.White.Type == api.Human && game.Players.Black.Type != api.Human:
+		rec.Color = api.White
+	case game.Players.Black.Type == api.Human && game.Players.White.Type != api.Human:
+		rec.Color = api.Black
+	}
+	rec.State = game.State
+	rec.Moves = len(game.Moves)
+	rec.Updated = now
+
+	if len(s.gameLog) > MaxGameLog {
+		sort.SliceStable(s.gameLog, func(a, b int) bool { return s.gameLog[a].Updated.After(s.gameLog[b].Updated) })
+		s.gameLog = s.gameLog[:MaxGameLog]
+	}
+	snap := s.snapshotGameLogLocked()
+	s.mu.Unlock()
+	s.saveGameLog(snap)
+}
+
+// ForgetGame removes a game from the log
+func (s *Session) ForgetGame(gameID string) {
+	s.mu.Lock()
+	var snap *gameLogSnapshot
+	if i := s.findRecordLocked(gameID, s.serverLocked()); i >= 0 {
+		s.gameLog = append(s.gameLog[:i], s.gameLog[i+1:]...)
+		snap = s.snapshotGameLogLocked()
+	}
+	s.mu.Unlock()
+	s.saveGameLog(snap)
+}
+
+// GameLog returns the games recorded for the active server, most recently
+// updated first
+func (s *Session) GameLog() []GameRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	server := s.serverLocked()
+	var out []GameRecord
+	for _, rec := range s.gameLog {
+		if rec.Server == server {
+			out = append(out, rec)
+		}
+	}
+	sort.SliceStable(out, func(a, b int) bool { return out[a].Updated.After(out[b].Updated) })
+	return out
+}
+
+// StartFEN returns the recorded start position of a game, "" if unknown
+func (s *Session) StartFEN(gameID string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if i := s.findRecordLocked(gameID, s.serverLocked()); i >= 0 {
+		return s.gameLog[i].StartFEN
+	}
+	return ""
+}
+
+// refreshRecordLocked updates the log entry of a game already in it and
+// returns the log to save, nil when nothing changed
+func (s *Session) refreshRecordLocked(game *api.GameResponse) *gameLogSnapshot {
+	i := s.findRecordLocked(game.GameID, s.serverLocked())
+	if i < 0 {
+		return nil
+	}
+	rec := &s.gameLog[i]
+	if rec.State == game.State && rec.Moves == len(game.Moves) {
+		return nil
+	}
+	rec.State = game.State
+	rec.Moves = len(game.Moves)
+	rec.Updated = time.Now()
+	return s.snapshotGameLogLocked()
+}
+
+func (s *Session) serverLocked() string {
+	if s.backend != s.primary {
+		return OfflineServer
+	}
+	return s.apiBaseURL
+}
+
+func (s *Session) findRecordLocked(gameID, server string) int {
+	for i, rec := range s.gameLog {
+		if rec.GameID == gameID && rec.Server == server {
+			return i
+		}
+	}
+	return -1
+}
+
+// gameLogSnapshot is the encoded log, written once s.mu is released so
+// moves and polls never wait on the disk
+type gameLogSnapshot struct {
+	path    string
+	data    []byte
+	version uint64
+}
+
+// snapshotGameLogLocked encodes the log after a change, nil when it is not
+// persisted
+func (s *Session) snapshotGameLogLocked() *gameLogSnapshot {
+	s.gameLogVersion++
+	if s.gameLogPath == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.gameLog, "", "  ")
+	if err != nil {
+		return nil
+	}
+	return &gameLogSnapshot{path: s.gameLogPath, data: data, version: s.gameLogVersion}
+}
+
+// saveGameLog writes a snapshot unless a newer one was written already;
+// failures are ignored since the log is a convenience and must never
+// interrupt play
+func (s *Session) saveGameLog(snap *gameLogSnapshot) {
+	if snap == nil {
+		return
+	}
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+	if snap.version <= s.savedVersion {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(snap.path), 0o755); err != nil {
+		return
+	}
+	tmp := snap.path + ".tmp"
+	if err := os.WriteFile(tmp, snap.data, 0o600); err != nil {
+		return
+	}
+	if os.Rename(tmp, snap.path) == nil {
+		s.savedVersion = snap.version
+	}
+}
@@ -0,0 +1,62 @@
+// FILE: lixenwraith/chess/internal/client/session/replay.go
+package session
+
+import (
+	"strings"
+
+	"chess/internal/client/api"
+	"chess/internal/client/rules"
+)
+
+// replay caches the history of the last game replayed
+type replay struct {
+	gameID  string
+	fen     string
+	plies   int
+	history *rules.History // nil if the game could not be replayed
+}
+
+// Replay returns the game replayed from its start position, with the
+// repetition count of every position, or nil when the moves do not lead to
+// the game's position from the recorded start or the standard one. The
+// history of the last game asked about is cached until it changes
+func (s *Session) Replay(game *api.GameResponse) *rules.History {
+	if game == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r := s.replay; r != nil && r.gameID == game.GameID && r.fen == game.FEN && r.plies == len(game.Moves) {
+		return r.history
+	}
+
+	candidates := []string{rules.StartFEN}
+	if i := s.findRecordLocked(game.GameID, s.serverLocked()); i >= 0 && s.gameLog[i].StartFEN != "" {
+		candidates = []string{s.gameLog[i].StartFEN, rules.StartFEN}
+	}
+	var history *rules.History
+	for _, fen := range candidates {
+		if history = ReplayFrom(fen, game); history != nil {
+			break
+		}
+	}
+	s.replay = &replay{gameID: game.GameID, fen: game.FEN, plies: len(game.Moves), history: history}
+	return history
+}
+
+// ReplayFrom returns the game replayed from fen, nil when its moves do not
+// lead from there to the game's position
+func ReplayFrom(fen string, game *api.GameResponse) *rules.History {
+	h, err := rules.Replay(fen, game.Moves)
+	if err != nil || !samePlacement(h.Position().FEN(), game.FEN) {
+		return nil
+	}
+	return h
+}
+
+// samePlacement compares placement, side to move and castling rights,
+// since servers differ on en passant and clocks
+func samePlacement(a, b string) bool {
+	fa, fb := strings.Fields(a), strings.Fields(b)
+	return len(fa) >= 3 && len(fb) >= 3 && strings.Join(fa[:3], " ") == strings.Join(fb[:3], " ")
+}
\ No newline at end of file
@@ -2,43 +2,232 @@
 package session
 
 import (
+	"sync"
+
 	"chess/internal/client/api"
 )
 
-// Session maintains client state and configuration
+// Session maintains client state and configuration, safe for concurrent use
 type Session struct {
-	APIBaseURL    string
-	CurrentGame   string
-	CurrentUser   string
-	AuthToken     string
-	Username      string
-	LastMoveCount int
-	Client        *api.Client
-	Verbose       bool
+	mu            sync.RWMutex
+	apiBaseURL    string
+	currentGame   string
+	currentUser   string
+	authToken     string
+	username      string
+	lastMoveCount int
+	primary       Backend
+	backend       Backend
+	verbose       bool
 	// Game state for prompt
-	CurrentGameState *api.GameResponse
-	PlayerColor      string // "w", "b", or ""
+	currentGameState *api.GameResponse
+	playerColor      api.Color
+	// Games created or joined, persisted when gameLogPath is set
+	gameLog        []GameRecord
+	gameLogPath    string
+	gameLogVersion uint64 // bumped by every change to gameLog
+	saveMu         sync.Mutex
+	savedVersion   uint64 // newest version written, guarded by saveMu
+	// Local engine playing one side, nil if none
+	engineSeat *EngineSeat
+	hintLevel  int
+	// Client-side clock of one game, nil if none
+	gameClock *GameClock
+	// Move history of the last game replayed, nil if none
+	replay *replay
+}
+
+// DefaultHintLevel is the engine level hints are searched at until changed
+const DefaultHintLevel = 10
+
+// New creates a session whose primary backend serves baseURL
+func New(baseURL string, primary Backend) *Session {
+	return &Session{
+		apiBaseURL: baseURL,
+		primary:    primary,
+		backend:    primary,
+		hintLevel:  DefaultHintLevel,
+	}
 }
 
 // Session interface implementation
-func (s *Session) GetAPIBaseURL() string      { return s.APIBaseURL }
-func (s *Session) SetAPIBaseURL(url string)   { s.APIBaseURL = url }
-func (s *Session) GetCurrentGame() string     { return s.CurrentGame }
-func (s *Session) SetCurrentGame(id string)   { s.CurrentGame = id }
-func (s *Session) GetCurrentUser() string     { return s.CurrentUser }
-func (s *Session) SetCurrentUser(id string)   { s.CurrentUser = id }
-func (s *Session) GetAuthToken() string       { return s.AuthToken }
-func (s *Session) SetAuthToken(token string)  { s.AuthToken = token }
-func (s *Session) GetUsername() string        { return s.Username }
-func (s *Session) SetUsername(name string)    { s.Username = name }
-func (s *Session) GetLastMoveCount() int      { return s.LastMoveCount }
-func (s *Session) SetLastMoveCount(count int) { s.LastMoveCount = count }
-func (s *Session) GetClient() any             { return s.Client }
-func (s *Session) IsVerbose() bool            { return s.Verbose }
+func (s *Session) GetAPIBaseURL() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.apiBaseURL
+}
+
+func (s *Session) SetAPIBaseURL(url string) {
+	s.mu.Lock()
+	s.apiBaseURL = url
+	s.mu.Unlock()
+}
+
+func (s *Session) GetCurrentGame() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.currentGame
+}
+
+func (s *Session) SetCurrentGame(id string) {
+	s.mu.Lock()
+	s.currentGame = id
+	s.mu.Unlock()
+}
+
+func (s *Session) GetCurrentUser() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.currentUser
+}
+
+func (s *Session) SetCurrentUser(id string) {
+	s.mu.Lock()
+	s.currentUser = id
+	s.mu.Unlock()
+}
+
+func (s *Session) GetAuthToken() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.authToken
+}
+
+func (s *Session) SetAuthToken(token string) {
+	s.mu.Lock()
+	s.authToken = token
+	s.mu.Unlock()
+}
+
+func (s *Session) GetUsername() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.username
+}
+
+func (s *Session) SetUsername(name string) {
+	s.mu.Lock()
+	s.username = name
+	s.mu.Unlock()
+}
+
+func (s *Session) GetLastMoveCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastMoveCount
+}
+
+func (s *Session) SetLastMoveCount(count int) {
+	s.mu.Lock()
+	s.lastMoveCount = count
+	s.mu.Unlock()
+}
+
+// GetBackend returns the active game backend
+func (s *Session) GetBackend() Backend {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.backend
+}
+
+// SetBackend switches the active backend, nil restores the primary one
+func (s *Session) SetBackend(b Backend) {
+	s.mu.Lock()
+	if b == nil {
+		b = s.primary
+	}
+	s.backend = b
+	s.mu.Unlock()
+}
+
+// IsOffline reports whether the primary backend has been swapped out
+func (s *Session) IsOffline() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.backend != s.primary
+}
+
+func (s *Session) IsVerbose() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.verbose
+}
+
+func (s *Session) SetVerbose(v bool) {
+	s.mu.Lock()
+	s.verbose = v
+	s.mu.Unlock()
+}
+
+// GetHintLevel returns the engine level used for hints
+func (s *Session) GetHintLevel() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.hintLevel
+}
+
+func (s *Session) SetHintLevel(level int) {
+	s.mu.Lock()
+	s.hintLevel = level
+	s.mu.Unlock()
+}
+
 func (s *Session) SetGameState(game any) {
 	if g, ok := game.(*api.GameResponse); ok {
-		s.CurrentGameState = g
+		s.mu.Lock()
+		s.currentGameState = g
+		snap := s.refreshRecordLocked(g)
+		s.tickClockLocked(g)
+		s.mu.Unlock()
+		s.saveGameLog(snap)
 	}
 }
-func (s *Session) SetPlayerColor(color string) { s.PlayerColor = color }
-func (s *Session) GetPlayerColor() string      { return s.PlayerColor }
\ No newline at end of file
+
+// GetGameState returns the last known game state, nil if none
+func (s *Session) GetGameState() *api.GameResponse {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.currentGameState
+}
+
+// ClearGame forgets the current game and its cached state
+func (s *Session) ClearGame() {
+	s.mu.Lock()
+	s.currentGame = ""
+	s.lastMoveCount = 0
+	s.currentGameState = nil
+	s.playerColor = api.NoColor
+	s.mu.Unlock()
+}
+
+// UpdateGame stores a fresh server response and its move count in one step
+func (s *Session) UpdateGame(game *api.GameResponse) {
+	s.mu.Lock()
+	s.lastMoveCount = len(game.Moves)
+	s.currentGameState = game
+	snap := s.refreshRecordLocked(game)
+	s.tickClockLocked(game)
+	s.mu.Unlock()
+	s.saveGameLog(snap)
+}
+
+// Authenticate records the authenticated identity in one step
+func (s *Session) Authenticate(token, userID, username string) {
+	s.mu.Lock()
+	s.authToken = token
+	s.currentUser = userID
+	s.username = username
+	s.mu.Unlock()
+}
+
+func (s *Session) SetPlayerColor(color api.Color) {
+	s.mu.Lock()
+	s.playerColor = color
+	s.mu.Unlock()
+}
+
+func (s *Session) GetPlayerColor() api.Color {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.playerColor
+}
@@ -0,0 +1,197 @@
+// FILE: lixenwraith/chess/internal/client/session/session_test.go
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/clienttest"
+	"chess/internal/client/rules"
+)
+
+// TestParallelGames plays several games at once through one client and one
+// session, as the prompt, the poller and commands do; run it with -race
+func TestParallelGames(t *testing.T) {
+	const (
+		games = 8
+		plies = 20
+	)
+	fake, srv := clienttest.NewServer()
+	defer srv.Close()
+	fake.SetPollTimeout(50 * time.Millisecond)
+
+	client := api.New(srv.URL)
+	client.SetQuiet(true)
+	s := New(srv.URL, client)
+	logPath := filepath.Join(t.TempDir(), "games.json")
+	if err := s.LoadGameLog(logPath); err != nil {
+		t.Fatal(err)
+	}
+
+	auth, err := client.Register("racer", "secret-password", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stop := make(chan struct{})
+	var background sync.WaitGroup
+	background.Add(1)
+	go func() {
+		// Configuration changes and reads racing the games
+		defer background.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			client.SetToken(auth.Token)
+			client.SetBaseURL(srv.URL)
+			s.Authenticate(auth.Token, auth.UserID, "racer")
+			s.SetVerbose(i%2 == 0)
+			s.SetHintLevel(i % 20)
+			_ = client.Stats()
+			_ = client.History()
+			_ = s.GetGameState()
+			_ = s.GameLog()
+			_ = s.IsOffline()
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, games)
+	for i := 0; i < games; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			if err := playRandomGame(s, seed, plies); err != nil {
+				errs <- err
+			}
+		}(int64(i))
+	}
+	wg.Wait()
+	close(stop)
+	background.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	if n := len(s.GameLog()); n != games {
+		t.Errorf("game log has %d games, want %d", n, games)
+	}
+
+	// The newest log reached the disk despite concurrent saves
+	reloaded := New(srv.URL, client)
+	if err := reloaded.LoadGameLog(logPath); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := json.Marshal(reloaded.GameLog())
+	want, _ := json.Marshal(s.GameLog())
+	if string(got) != string(want) {
+		t.Errorf("saved log differs from the session's:\n%s\n%s", got, want)
+	}
+}
+
+func TestGameLogSavedOnChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "games.json")
+	s := New("http://localhost:8080", api.New("http://localhost:8080"))
+	if err := s.LoadGameLog(path); err != nil {
+		t.Fatal(err)
+	}
+	game := &api.GameResponse{GameID: "g1", FEN: rules.StartFEN, Turn: api.White, State: api.StateOngoing}
+	s.RecordGame(game)
+
+	// An unchanged game, as every poll returns, does not touch the disk
+	os.Remove(path)
+	s.UpdateGame(game)
+	s.SetGameState(game)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("unchanged game rewrote the log: %v", err)
+	}
+
+	s.UpdateGame(&api.GameResponse{GameID: "g1", Turn: api.Black, State: api.StateOngoing, Moves: []string{"e2e4"}})
+	reloaded := New("http://localhost:8080", nil)
+	if err := reloaded.LoadGameLog(path); err != nil {
+		t.Fatal(err)
+	}
+	if log := reloaded.GameLog(); len(log) != 1 || log[0].Moves != 1 {
+		t.Fatalf("saved log = %+v, want g1 with one move", log)
+	}
+
+	s.ForgetGame("g1")
+	reloaded = New("http://localhost:8080", nil)
+	if err := reloaded.LoadGameLog(path); err != nil {
+		t.Fatal(err)
+	}
+	if log := reloaded.GameLog(); len(log) != 0 {
+		t.Fatalf("forgotten game still saved: %+v", log)
+	}
+}
+
+// playRandomGame plays random legal moves in a new game while a second
+// goroutine long-polls it
+func playRandomGame(s *Session, seed int64, plies int) error {
+	rng := rand.New(rand.NewSource(seed))
+	c := s.GetBackend()
+
+	game, err := c.CreateGame(&api.CreateGameRequest{
+		White: api.PlayerConfig{Type: api.Human},
+		Black: api.PlayerConfig{Type: api.Human},
+	})
+	if err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
+	s.SetCurrentGame(game.GameID)
+	s.RecordGame(game)
+
+	gameID := game.GameID
+	done := make(chan struct{})
+	polled := make(chan error, 1)
+	go func() {
+		seen := 0
+		for {
+			select {
+			case <-done:
+				polled <- nil
+				return
+			default:
+			}
+			g, err := c.GetGameWithPoll(gameID, seen)
+			if err != nil {
+				polled <- fmt.Errorf("poll %s: %w", gameID, err)
+				return
+			}
+			seen = len(g.Moves)
+			s.UpdateGame(g)
+		}
+	}()
+
+	for ply := 0; ply < plies && game.State == api.StateOngoing; ply++ {
+		pos, err := rules.ParseFEN(game.FEN)
+		if err != nil {
+			close(done)
+			return err
+		}
+		moves := pos.LegalMoves()
+		next, err := c.MakeMove(gameID, moves[rng.Intn(len(moves))].String())
+		if err != nil {
+			close(done)
+			return fmt.Errorf("move in %s: %w", gameID, err)
+		}
+		game = next
+		s.UpdateGame(game)
+		_ = s.GetLastMoveCount()
+		_ = s.GetCurrentGame()
+	}
+	close(done)
+	return <-polled
+}
\ No newline at end of file
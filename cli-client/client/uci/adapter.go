@@ -0,0 +1,354 @@
+// FILE: lixenwraith/chess/internal/client/uci/adapter.go
+// Package uci speaks the Universal Chess Interface so the chess server's
+// engine can be used from chess GUIs.
+package uci
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/engine"
+	"chess/internal/client/rules"
+)
+
+// defaultMovesToGo spreads the remaining clock when the GUI gives none
+const defaultMovesToGo = 30
+
+// SearchFunc finds a move for the side to move within the time budget
+type SearchFunc func(pos *rules.Position, level int, budget time.Duration) (*api.MoveInfo, error)
+
+// Adapter answers UCI commands, handing every search to its SearchFunc
+type Adapter struct {
+	name     string
+	client   *api.Client // server searched by default, nil for other searches
+	searcher SearchFunc
+	level    int
+
+	outMu sync.Mutex
+	out   io.Writer
+
+	pos     *rules.Position
+	running *search // in flight, nil when idle
+}
+
+type search struct {
+	stop chan struct{} // closed by 'stop' or 'quit'
+	done chan struct{} // closed once bestmove is sent
+}
+
+// goParams holds the limits of one 'go' command
+type goParams struct {
+	moveTime, wtime, btime, winc, binc time.Duration
+	movesToGo, depth                   int
+	infinite                           bool
+}
+
+// NewAdapter returns an adapter that searches on the server at the given
+// engine level: each search creates a game from the position with the side
+// to move played by the computer, triggers its move and reports it as the
+// best move, with the limits described at api.SearchPosition
+func NewAdapter(client *api.Client, level int) *Adapter {
+	a := NewFuncAdapter("chess-uci", level, nil)
+	a.client = client
+	a.searcher = a.searchServer
+	return a
+}
+
+// NewFuncAdapter returns an adapter answering searches with fn, for fake
+// and scripted engines
+func NewFuncAdapter(name string, level int, fn SearchFunc) *Adapter {
+	return &Adapter{
+		name:     name,
+		searcher: fn,
+		level:    max(0, min(level, engine.MaxLevel)),
+		pos:      rules.Start(),
+	}
+}
+
+// Run reads UCI commands from in and writes responses to out until 'quit'
+// or the end of input
+func (a *Adapter) Run(in io.Reader, out io.Writer) error {
+	a.out = out
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch cmd, args := fields[0], fields[1:]; cmd {
+		case "uci":
+			a.send("id name %s", a.name)
+			a.send("id author lixenwraith")
+			a.send("option name Level type spin default %d min 0 max %d", a.level, engine.MaxLevel)
+			if a.client != nil {
+				a.send("option name Server type string default %s", a.client.BaseURL())
+			}
+			a.send("uciok")
+
+		case "isready":
+			a.send("readyok")
+
+		case "setoption":
+			a.wait()
+			a.setOption(args)
+
+		case "ucinewgame":
+			a.wait()
+			a.pos = rules.Start()
+
+		case "position":
+			a.wait()
+			pos, err := parsePosition(args)
+			if err != nil {
+				a.send("info string %s", err.Error())
+				continue
+			}
+			a.pos = pos
+
+		case "go":
+			a.wait()
+			a.startSearch(parseGo(args))
+
+		case "stop":
+			a.stopSearch()
+			a.wait()
+
+		case "quit":
+			a.stopSearch()
+			a.wait()
+			return nil
+		}
+		// Other commands such as debug, register and ponderhit are ignored
+	}
+	a.stopSearch()
+	a.wait()
+	return scanner.Err()
+}
+
+func (a *Adapter) send(format string, args ...any) {
+	a.outMu.Lock()
+	defer a.outMu.Unlock()
+	fmt.Fprintf(a.out, format+"\n", args...)
+}
+
+// setOption handles "setoption name <name> value <value>"
+func (a *Adapter) setOption(args []string) {
+	joined := strings.Join(args, " ")
+	name, value, _ := strings.Cut(strings.TrimPrefix(joined, "name "), " value ")
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "level":
+		level, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || level < 0 || level > engine.MaxLevel {
+			a.send("info string Level must be 0-%d", engine.MaxLevel)
+			return
+		}
+		a.level = level
+	case "server":
+		if a.client == nil {
+			a.send("info string unknown option %q", name)
+			return
+		}
+		url := strings.TrimSpace(value)
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			url = "http://" + url
+		}
+		a.client.SetBaseURL(url)
+	default:
+		a.send("info string unknown option %q", name)
+	}
+}
+
+func (a *Adapter) startSearch(params goParams) {
+	s := &search{stop: make(chan struct{}), done: make(chan struct{})}
+	a.running = s
+
+	pos, level := a.pos, a.level
+	if params.depth > 0 {
+		// Pick the weakest level whose depth limit reaches the requested depth
+		level = 0
+		for level < engine.MaxLevel && engine.MaxDepth(level) < params.depth {
+			level++
+		}
+	}
+	budget := params.budget(pos.Turn)
+
+	go func() {
+		defer close(s.done)
+		best := "0000"
+		if len(pos.LegalMoves()) > 0 {
+			info, err := a.searcher(pos, level, budget)
+			if err != nil {
+				// GUIs need a move, fall back to the first legal one
+				a.send("info string search failed: %s", err.Error())
+				best = pos.LegalMoves()[0].String()
+			} else {
+				a.sendInfo(info)
+				best = info.Move
+			}
+		}
+		// In infinite mode bestmove must wait for 'stop'
+		if params.infinite {
+			<-s.stop
+		}
+		a.send("bestmove %s", best)
+	}()
+}
+
+// stopSearch asks the running search to finish. Searches cannot
+// be interrupted, so the best move is sent once it arrives
+func (a *Adapter) stopSearch() {
+	if a.running == nil {
+		return
+	}
+	select {
+	case <-a.running.stop:
+	default:
+		close(a.running.stop)
+	}
+}
+
+// wait blocks until any running search has sent its best move
+func (a *Adapter) wait() {
+	if a.running != nil {
+		<-a.running.done
+		a.running = nil
+	}
+}
+
+// searchServer has the server engine search the position
+func (a *Adapter) searchServer(pos *rules.Position, level int, budget time.Duration) (*api.MoveInfo, error) {
+	return api.SearchPosition(a.client, pos.FEN(), level, budget)
+}
+
+// sendInfo reports the search depth and score of the chosen move; scores
+// beyond the mate threshold are converted to moves to mate
+func (a *Adapter) sendInfo(info *api.MoveInfo) {
+	var b strings.Builder
+	b.WriteString("info")
+	if info.Depth > 0 {
+		fmt.Fprintf(&b, " depth %d", info.Depth)
+	}
+	switch score := info.Score; {
+	case score >= engine.MateScore-1000:
+		fmt.Fprintf(&b, " score mate %d", (engine.MateScore-score+1)/2)
+	case score <= -engine.MateScore+1000:
+		fmt.Fprintf(&b, " score mate -%d", (engine.MateScore+score)/2)
+	default:
+		fmt.Fprintf(&b, " score cp %d", score)
+	}
+	fmt.Fprintf(&b, " pv %s", info.Move)
+	a.send("%s", b.String())
+}
+
+// parsePosition handles "position (startpos | fen <fen>) [moves <move>...]"
+func parsePosition(args []string) (*rules.Position, error) {
+	setup, moves := args, []string(nil)
+	for i, arg := range args {
+		if arg == "moves" {
+			setup, moves = args[:i], args[i+1:]
+			break
+		}
+	}
+	if len(setup) == 0 {
+		return nil, fmt.Errorf("position needs startpos or fen")
+	}
+
+	var pos *rules.Position
+	switch setup[0] {
+	case "startpos":
+		pos = rules.Start()
+	case "fen":
+		fields := setup[1:]
+		// Some GUIs leave out the move counters
+		if len(fields) == 4 {
+			fields = append(fields, "0", "1")
+		}
+		p, err := rules.ParseFEN(strings.Join(fields, " "))
+		if err != nil {
+			return nil, err
+		}
+		pos = p
+	default:
+		return nil, fmt.Errorf("position needs startpos or fen, got %q", setup[0])
+	}
+
+	for _, uci := range moves {
+		m, err := pos.ParseMove(uci)
+		if err != nil {
+			return nil, err
+		}
+		pos = pos.Apply(m)
+	}
+	return pos, nil
+}
+
+// parseGo reads the limits of a 'go' command; unknown tokens are skipped
+func parseGo(args []string) goParams {
+	var p goParams
+	for i := 0; i < len(args); i++ {
+		if args[i] == "infinite" {
+			p.infinite = true
+			continue
+		}
+		if i+1 >= len(args) {
+			break
+		}
+		n, err := strconv.Atoi(args[i+1])
+		if err != nil {
+			continue
+		}
+		ms := time.Duration(n) * time.Millisecond
+		switch args[i] {
+		case "movetime":
+			p.moveTime = ms
+		case "wtime":
+			p.wtime = ms
+		case "btime":
+			p.btime = ms
+		case "winc":
+			p.winc = ms
+		case "binc":
+			p.binc = ms
+		case "movestogo":
+			p.movesToGo = n
+		case "depth":
+			p.depth = n
+		default:
+			continue
+		}
+		i++
+	}
+	return p
+}
+
+// budget turns the limits into a server search time for the side to move
+func (p goParams) budget(turn rules.Color) time.Duration {
+	left, inc := p.wtime, p.winc
+	if turn == rules.Black {
+		left, inc = p.btime, p.binc
+	}
+
+	var t time.Duration
+	switch {
+	case p.moveTime > 0:
+		t = p.moveTime
+	case p.infinite, p.depth > 0 && left == 0:
+		t = api.MaxSearchTime
+	case left > 0:
+		movesToGo := p.movesToGo
+		if movesToGo <= 0 {
+			movesToGo = defaultMovesToGo
+		}
+		t = min(left/time.Duration(movesToGo)+inc, left/2)
+	default:
+		t = engine.DefaultSearchTime
+	}
+	return max(api.MinSearchTime, min(t, api.MaxSearchTime))
+}
\ No newline at end of file
@@ -0,0 +1,155 @@
+// FILE: lixenwraith/chess/internal/client/uci/adapter_test.go
+package uci
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/rules"
+)
+
+// after returns the FEN reached by playing moves from fen
+func after(t *testing.T, fen string, moves ...string) string {
+	t.Helper()
+	h, err := rules.Replay(fen, moves)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h.Position().FEN()
+}
+
+func TestAdapter(t *testing.T) {
+	const kings = "4k3/8/8/8/8/8/8/4K2R w K - 0 1"
+	cases := []struct {
+		name     string
+		input    []string
+		want     []string // lines expected in the output, in order
+		notWant  []string // substrings that must not appear
+		searched []string // FENs handed to the search function, in order
+	}{
+		{
+			name:  "handshake",
+			input: []string{"uci", "isready"},
+			want:  []string{"id name test", "option name Level type spin default 2 min 0", "uciok", "readyok"},
+		},
+		{
+			name:     "startpos with moves",
+			input:    []string{"position startpos moves e2e4 e7e5", "go movetime 100"},
+			want:     []string{"info depth 1 score cp 0 pv", "bestmove"},
+			searched: []string{after(t, rules.StartFEN, "e2e4", "e7e5")},
+		},
+		{
+			name:     "fen with moves",
+			input:    []string{"position fen " + kings + " moves e1g1", "go"},
+			want:     []string{"bestmove"},
+			searched: []string{after(t, kings, "e1g1")},
+		},
+		{
+			name:     "fen without move counters",
+			input:    []string{"position fen 4k3/8/8/8/8/8/8/4K2R w K -", "go wtime 1000 btime 1000"},
+			want:     []string{"bestmove"},
+			searched: []string{kings},
+		},
+		{
+			name:     "moves without a setup",
+			input:    []string{"position moves e2e4", "isready", "go depth 1"},
+			want:     []string{"info string position needs startpos or fen", "readyok", "bestmove"},
+			searched: []string{rules.StartFEN},
+		},
+		{
+			name:     "empty position",
+			input:    []string{"position", "go"},
+			want:     []string{"info string position needs startpos or fen", "bestmove"},
+			searched: []string{rules.StartFEN},
+		},
+		{
+			name:     "unknown setup keeps the last position",
+			input:    []string{"position startpos moves d2d4", "position banana", "go"},
+			want:     []string{`info string position needs startpos or fen, got "banana"`, "bestmove"},
+			searched: []string{after(t, rules.StartFEN, "d2d4")},
+		},
+		{
+			name:  "illegal move and bad fen",
+			input: []string{"position startpos moves e2e5", "position fen 9/8/8/8/8/8/8/8 w - - 0 1"},
+			want:  []string{"info string", "info string"},
+		},
+		{
+			name:     "malformed go limits",
+			input:    []string{"go movetime soon wtime", "go movetime"},
+			want:     []string{"bestmove", "bestmove"},
+			searched: []string{rules.StartFEN, rules.StartFEN},
+		},
+		{
+			name:     "infinite search waits for stop",
+			input:    []string{"go infinite", "isready", "stop"},
+			want:     []string{"readyok", "bestmove"},
+			searched: []string{rules.StartFEN},
+		},
+		{
+			name:    "no legal move",
+			input:   []string{"position fen k7/1Q6/1K6/8/8/8/8/8 b - - 0 1", "go"},
+			want:    []string{"bestmove 0000"},
+			notWant: []string{"info depth"},
+		},
+		{
+			name:    "quit ends the session",
+			input:   []string{"isready", "quit", "isready"},
+			want:    []string{"readyok"},
+			notWant: []string{"readyok\nreadyok"},
+		},
+		{
+			name:     "quit waits for the search",
+			input:    []string{"go infinite", "quit"},
+			want:     []string{"bestmove"},
+			searched: []string{rules.StartFEN},
+		},
+		{
+			name:  "options",
+			input: []string{"setoption name Level value 9", "setoption name Level value x", "setoption name Server value localhost", "debug on"},
+			want:  []string{"info string Level must be 0-", `info string unknown option "Server"`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var searched []string
+			a := NewFuncAdapter("test", 2, func(pos *rules.Position, level int, budget time.Duration) (*api.MoveInfo, error) {
+				mu.Lock()
+				searched = append(searched, pos.FEN())
+				mu.Unlock()
+				if budget < api.MinSearchTime || budget > api.MaxSearchTime {
+					t.Errorf("budget %s outside the search limits", budget)
+				}
+				return &api.MoveInfo{Move: pos.LegalMoves()[0].String(), Depth: 1}, nil
+			})
+
+			var out strings.Builder
+			if err := a.Run(strings.NewReader(strings.Join(tc.input, "\n")+"\n"), &out); err != nil {
+				t.Fatal(err)
+			}
+			got := out.String()
+			rest := got
+			for _, w := range tc.want {
+				i := strings.Index(rest, w)
+				if i < 0 {
+					t.Fatalf("output lacks %q in order:\n%s", w, got)
+				}
+				rest = rest[i+len(w):]
+			}
+			for _, w := range tc.notWant {
+				if strings.Contains(got, w) {
+					t.Fatalf("output contains %q:\n%s", w, got)
+				}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if strings.Join(searched, "\n") != strings.Join(tc.searched, "\n") {
+				t.Fatalf("searched %q, want %q", searched, tc.searched)
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,271 @@
+// FILE: lixenwraith/chess/internal/client/uci/engine.go
+package uci
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"chess/internal/client/api"
+	"chess/internal/client/engine"
+)
+
+const (
+	// handshakeTimeout bounds the wait for uciok and readyok, and for the
+	// late best move of a search given up on
+	handshakeTimeout = 10 * time.Second
+	// quitTimeout is how long an engine gets to exit before it is killed
+	quitTimeout = 2 * time.Second
+)
+
+// moveGrace is how long past the move time an engine may take before it is
+// told to stop, and again before it is given up on; tests shorten it
+var moveGrace = 5 * time.Second
+
+// Engine is a UCI engine running as a subprocess
+type Engine struct {
+	Name string // reported by the engine, the binary name until then
+	Path string
+
+	mu    sync.Mutex // serializes commands
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	lines chan string // engine output, closed when it exits
+	owed  bool        // a search was given up on and its bestmove is still due
+}
+
+// Start launches the engine binary and completes the UCI handshake
+func Start(path string, args ...string) (*Engine, error) {
+	cmd := exec.Command(path, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start engine: %w", err)
+	}
+
+	e := &Engine{
+		Name:  filepath.Base(path),
+		Path:  path,
+		cmd:   cmd,
+		stdin: stdin,
+		lines: make(chan string, 64),
+	}
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			e.lines <- scanner.Text()
+		}
+		close(e.lines)
+	}()
+
+	e.send("uci")
+	_, err = e.expect("uciok", handshakeTimeout, func(line string) {
+		if name, ok := strings.CutPrefix(line, "id name "); ok {
+			e.Name = strings.TrimSpace(name)
+		}
+	})
+	if err == nil {
+		err = e.sync()
+	}
+	if err != nil {
+		e.Close()
+		return nil, err
+	}
+	return e, nil
+}
+
+// SetOption sets an engine option, e.g. "Skill Level" to "5"
+func (e *Engine) SetOption(name, value string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := e.send("setoption name %s value %s", name, value); err != nil {
+		return err
+	}
+	return e.sync()
+}
+
+// NewGame tells the engine the following searches belong to a new game
+func (e *Engine) NewGame() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := e.send("ucinewgame"); err != nil {
+		return err
+	}
+	return e.sync()
+}
+
+// BestMove searches the position reached by playing moves from fen, or from
+// the start position when fen is empty. Depth and Score are taken from the
+// last info line the engine sent
+func (e *Engine) BestMove(fen string, moves []string, moveTime time.Duration) (*api.MoveInfo, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// A late bestmove must not be taken as the answer to this search
+	if err := e.drain(); err != nil {
+		return nil, err
+	}
+
+	position := "startpos"
+	if fen != "" {
+		position = "fen " + fen
+	}
+	if len(moves) > 0 {
+		position += " moves " + strings.Join(moves, " ")
+	}
+	if err := e.send("position %s", position); err != nil {
+		return nil, err
+	}
+	if err := e.send("go movetime %d", moveTime.Milliseconds()); err != nil {
+		return nil, err
+	}
+
+	info := &api.MoveInfo{}
+	seen := func(line string) { parseInfo(line, info) }
+	line, err := e.expect("bestmove", moveTime+moveGrace, seen)
+	if err != nil && e.send("stop") == nil {
+		line, err = e.expect("bestmove", moveGrace, seen)
+		e.owed = err != nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) < 2 || fields[1] == "(none)" || fields[1] == "0000" {
+		return nil, fmt.Errorf("%s has no move in this position", e.Name)
+	}
+	info.Move = fields[1]
+	return info, nil
+}
+
+// Close asks the engine to quit and kills it if it does not
+func (e *Engine) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.send("quit")
+	e.stdin.Close()
+	// Keep the reader from blocking so the process can be reaped
+	go func() {
+		for range e.lines {
+		}
+	}()
+
+	done := make(chan error, 1)
+	go func() { done <- e.cmd.Wait() }()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(quitTimeout):
+		e.cmd.Process.Kill()
+		return <-done
+	}
+}
+
+func (e *Engine) send(format string, args ...any) error {
+	if _, err := fmt.Fprintf(e.stdin, format+"\n", args...); err != nil {
+		return fmt.Errorf("write to %s: %w", e.Name, err)
+	}
+	return nil
+}
+
+// sync waits until the engine has processed everything sent so far
+func (e *Engine) sync() error {
+	if err := e.send("isready"); err != nil {
+		return err
+	}
+	_, err := e.expect("readyok", handshakeTimeout, nil)
+	return err
+}
+
+// drain discards the bestmove still due from a search given up on, then
+// waits until the engine has processed everything sent before it
+func (e *Engine) drain() error {
+	if !e.owed {
+		return nil
+	}
+	if _, err := e.expect("bestmove", handshakeTimeout, nil); err != nil {
+		return fmt.Errorf("%s is still busy with an abandoned search: %w", e.Name, err)
+	}
+	return e.sync()
+}
+
+// expect reads engine output up to the first line starting with token,
+// handing the lines before it to seen. A bestmove read on the way settles
+// an owed one
+func (e *Engine) expect(token string, timeout time.Duration, seen func(string)) (string, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case line, ok := <-e.lines:
+			if !ok {
+				return "", fmt.Errorf("%s exited", e.Name)
+			}
+			fields := strings.Fields(line)
+			if len(fields) > 0 && fields[0] == "bestmove" && e.owed {
+				e.owed = false
+				if token == "bestmove" {
+					return line, nil
+				}
+				continue
+			}
+			if len(fields) > 0 && fields[0] == token {
+				return line, nil
+			}
+			if seen != nil {
+				seen(line)
+			}
+		case <-timer.C:
+			return "", fmt.Errorf("%s sent no %s within %s", e.Name, token, timeout)
+		}
+	}
+}
+
+// parseInfo copies depth and score from an info line, mate scores mapped
+// onto the engine package scale
+func parseInfo(line string, info *api.MoveInfo) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || fields[0] != "info" {
+		return
+	}
+	for i := 1; i+1 < len(fields); i++ {
+		switch fields[i] {
+		case "depth":
+			if n, err := strconv.Atoi(fields[i+1]); err == nil {
+				info.Depth = n
+			}
+		case "score":
+			if i+2 >= len(fields) {
+				return
+			}
+			n, err := strconv.Atoi(fields[i+2])
+			if err != nil {
+				continue
+			}
+			switch fields[i+1] {
+			case "cp":
+				info.Score = n
+			case "mate":
+				if n > 0 {
+					info.Score = engine.MateScore - (2*n - 1)
+				} else {
+					info.Score = -engine.MateScore - 2*n
+				}
+			}
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,81 @@
+// FILE: lixenwraith/chess/internal/client/uci/engine_test.go
+package uci
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"chess/internal/client/clienttest"
+)
+
+func startFake(t *testing.T, args ...string) *Engine {
+	t.Helper()
+	e, err := Start(clienttest.FakeEngine(t), args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { e.Close() })
+	return e
+}
+
+func TestBestMove(t *testing.T) {
+	e := startFake(t, "-name", "scripted", "-moves", "e2e4,e7e5,h1h8")
+	if e.Name != "scripted" {
+		t.Fatalf("name = %q, want the engine's id name", e.Name)
+	}
+	if err := e.NewGame(); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name  string
+		fen   string
+		moves []string
+		want  string
+		err   string
+	}{
+		{name: "start position", want: "e2e4"},
+		{name: "after moves", moves: []string{"e2e4"}, want: "e7e5"},
+		{name: "from fen", fen: "4k3/8/8/8/8/8/8/4K2R w K - 0 1", want: "h1h8"},
+		{name: "no legal move", fen: "k7/1Q6/1K6/8/8/8/8/8 b - - 0 1", err: "no move"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			info, err := e.BestMove(tc.fen, tc.moves, 100*time.Millisecond)
+			switch {
+			case tc.err != "":
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("err = %v, want %q", err, tc.err)
+				}
+			case err != nil:
+				t.Fatal(err)
+			case info.Move != tc.want || info.Depth != 1:
+				t.Fatalf("info = %+v, want %s at depth 1", info, tc.want)
+			}
+		})
+	}
+}
+
+func TestLateBestMoveDrained(t *testing.T) {
+	grace := moveGrace
+	moveGrace = 100 * time.Millisecond
+	t.Cleanup(func() { moveGrace = grace })
+
+	e := startFake(t, "-moves", "e2e4,d2d4", "-stall", "500ms")
+	if _, err := e.BestMove("", nil, 100*time.Millisecond); err == nil {
+		t.Fatal("stalled search did not time out")
+	}
+
+	// The stalled search's e2e4 arrives late and must not answer this one
+	info, err := e.BestMove("", nil, 100*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Move != "d2d4" {
+		t.Fatalf("move = %s, want d2d4 rather than the late e2e4", info.Move)
+	}
+	if err := e.SetOption("Level", "3"); err != nil {
+		t.Fatal(err)
+	}
+}
\ No newline at end of file
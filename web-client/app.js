@@ -121,6 +121,10 @@ function updateTurnIndicator(state, turn) {
                 status = 'black-wins';
                 tooltipText = 'Black Wins';
                 break;
+            case 'checkmate':
+                status = 'unknown';
+                tooltipText = 'Checkmate';
+                break;
             case 'stalemate':
                 status = 'stalemate';
                 tooltipText = 'Stalemate';
@@ -279,7 +283,7 @@ async function startNewGame() {
             throw new Error(errorInfo.statusMessage);
         }
 
-        const game = await response.json();
+        const game = await readGame(response);
         gameState.gameId = game.gameId;
         gameState.moveList = [];
         hideNewGameModal();
@@ -411,7 +415,7 @@ async function handleHumanMove(from, to) {
             body: JSON.stringify({ move })
         });
 
-        const game = await response.json();
+        const game = await readGame(response);
         if (!response.ok) {
             // Handle client errors differently - these aren't network issues
             if (response.status === 400) {
@@ -481,7 +485,7 @@ function startPolling() {
                 return;
             }
 
-            const game = await response.json();
+            const game = await readGame(response);
             if (game.state !== 'pending') {
                 stopPolling();
                 updateGameDisplay(game);
@@ -537,8 +541,7 @@ async function undoMoves() {
             throw new Error(errorInfo.statusMessage);
         }
 
-        const game = await response.json();
-        gameState.state = game.state;
+        const game = await readGame(response);
         updateGameDisplay(game);
     } catch (error) {
         if (error.message === 'Failed to fetch') {
@@ -652,8 +655,43 @@ function markMatedKing(game) {
     });
 }
 
+// Decode a game response with its state already normalized, so every check
+// after a fetch compares canonical states only
+async function readGame(response) {
+    const game = await response.json();
+    game.state = normalizeState(game.state, game.turn);
+    return game;
+}
+
+// Map every server spelling of a game state to the canonical form; a bare
+// checkmate is resolved from the side to move, which is the mated side, and
+// kept as 'checkmate' when the side to move is unknown
+function normalizeState(state, turn) {
+    const s = (state || '').toLowerCase().replace(/[\s_-]+/g, ' ').trim();
+    switch (s) {
+        case 'white wins': case 'whitewins': case '1 0': case 'white won':
+            return 'white wins';
+        case 'black wins': case 'blackwins': case '0 1': case 'black won':
+            return 'black wins';
+        case 'checkmate': case 'mate':
+            if (turn === 'w') return 'black wins';
+            if (turn === 'b') return 'white wins';
+            return 'checkmate';
+        case 'stalemate':
+            return 'stalemate';
+        case 'draw': case 'drawn': case '1/2 1/2':
+            return 'draw';
+        case 'ongoing': case 'active': case 'in progress': case 'playing':
+            return 'ongoing';
+        case 'pending': case 'thinking':
+            return 'pending';
+        default:
+            return s;
+    }
+}
+
 function isGameOver(state) {
-    return ['white wins', 'black wins', 'stalemate', 'draw'].includes(state);
+    return ['white wins', 'black wins', 'checkmate', 'stalemate', 'draw'].includes(state);
 }
 
 function handleApiError(action, error, response = null) {